@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 Maxim Levchenko (WoozyMasta)
+// Source: github.com/woozymasta/lzss
+
+package lzss
+
+import "math/bits"
+
+// OffsetBuckets is the number of offset histogram buckets: bucket k counts offsets in [2^k, 2^(k+1)).
+const OffsetBuckets = 12
+
+// Stats describes the token mix of a compressed block, as produced by Analyze.
+type Stats struct {
+	// MatchLengths counts pointers by match length (index is the length, 2..18).
+	MatchLengths [MaxMatch + 1]int
+	// SavedByLength sums bytes saved by pointers of each length (length - 2 pointer bytes).
+	SavedByLength [MaxMatch + 1]int
+	// Offsets counts pointers by offset bucket, see OffsetBuckets.
+	Offsets [OffsetBuckets]int
+
+	InputSize   int   // Decoded size.
+	OutputSize  int   // Compressed size including flag bytes and checksum.
+	Literals    int   // Literal slots.
+	Pointers    int   // Pointer slots.
+	FlagBytes   int   // Flag bytes (one per 8 slots).
+	MaxOffset   int   // Largest pointer offset.
+	SearchLimit int   // Effective search limit used by Compress (0 = literals only).
+	offsetSum   int64 // Sum of pointer offsets for MeanOffset.
+}
+
+// Analyze compresses src with opts and reports the token mix of the result.
+// Options nil means DefaultCompressOptions(). Empty src returns zero Stats.
+func Analyze(src []byte, opts *CompressOptions) Stats {
+	if opts == nil {
+		opts = DefaultCompressOptions()
+	}
+
+	enc, err := Compress(src, opts)
+	if err != nil {
+		return Stats{}
+	}
+
+	st := Stats{
+		InputSize:   len(src),
+		OutputSize:  len(enc),
+		SearchLimit: min(max(opts.SearchLimit, 0), WindowSize-1),
+	}
+
+	// Walk tokens of own output; the block is valid, so no errors are possible.
+	w := newTokenWalker(&sliceByteReader{data: enc}, &Options{MinMatchLength: opts.MinMatchLength})
+	for {
+		t, ok, _ := w.next(len(src))
+		if !ok {
+			break
+		}
+		if t.literal {
+			st.Literals++
+			continue
+		}
+
+		st.Pointers++
+		st.MatchLengths[t.length]++
+		st.SavedByLength[t.length] += t.length - 2
+		st.Offsets[bits.Len(uint(t.offset))-1]++
+		st.MaxOffset = max(st.MaxOffset, t.offset)
+		st.offsetSum += int64(t.offset)
+	}
+
+	// Compress starts a flag byte only for a following token.
+	st.FlagBytes = (st.Literals + st.Pointers + FlagBits - 1) / FlagBits
+
+	return st
+}
+
+// Ratio returns OutputSize / InputSize (0 for empty input).
+func (s *Stats) Ratio() float64 {
+	if s.InputSize == 0 {
+		return 0
+	}
+
+	return float64(s.OutputSize) / float64(s.InputSize)
+}
+
+// MatchedBytes returns decoded bytes produced by pointers.
+func (s *Stats) MatchedBytes() int {
+	total := 0
+	for length, count := range s.MatchLengths {
+		total += length * count
+	}
+
+	return total
+}
+
+// Saved returns total bytes saved by pointers.
+func (s *Stats) Saved() int {
+	total := 0
+	for _, saved := range s.SavedByLength {
+		total += saved
+	}
+
+	return total
+}
+
+// MeanOffset returns the average pointer offset (0 without pointers).
+func (s *Stats) MeanOffset() float64 {
+	if s.Pointers == 0 {
+		return 0
+	}
+
+	return float64(s.offsetSum) / float64(s.Pointers)
+}
+
+// WindowUse returns MaxOffset as a fraction of SearchLimit: values well below 1
+// mean a smaller SearchLimit would find the same matches faster.
+func (s *Stats) WindowUse() float64 {
+	if s.SearchLimit == 0 {
+		return 0
+	}
+
+	return float64(s.MaxOffset) / float64(s.SearchLimit)
+}
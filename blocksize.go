@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 Maxim Levchenko (WoozyMasta)
+// Source: github.com/woozymasta/lzss
+
+package lzss
+
+import (
+	"bytes"
+	"io"
+)
+
+// BlockSize returns the compressed size (data + 4-byte checksum) of the block at the start of src
+// without decoding it: only flag bits and pointer lengths are walked, no output is allocated,
+// and the checksum is not verified. Strict options still apply their checks.
+// Errors are *DecodeError with the same positions as DecompressBlock.
+func BlockSize(src []byte, outLen int, opts *Options) (int, error) {
+	if len(src) < 4 {
+		return 0, ErrInputTooShort
+	}
+
+	reader := &sliceByteReader{data: src}
+	if err := walkBlock(reader, outLen, opts); err != nil {
+		return 0, err
+	}
+
+	return reader.pos, nil
+}
+
+// BlockSizeFromReader is like BlockSize for a stream: it reads exactly one block from r
+// and returns its compressed size, leaving r positioned after the block as DecompressFromReader does.
+func BlockSizeFromReader(r io.Reader, outLen int, opts *Options) (int64, error) {
+	countingReader, err := newCountingByteReader(r)
+	if err != nil {
+		return 0, err
+	}
+
+	err = releaseReader(countingReader, walkBlock(countingReader, outLen, opts))
+
+	return countingReader.count, err
+}
+
+// MaxDecodedSize returns the largest outLen a block of packedLen bytes (data + 4-byte checksum)
+// can decode to: a pointer with its flag bit (17 bits) yields at most MinMatchLength+15 bytes.
+// Containers use it to reject corrupt size fields before allocating outLen bytes.
+// Options nil means DefaultOptions().
+func MaxDecodedSize(packedLen int64, opts *Options) int64 {
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+	if packedLen <= 4 {
+		return 0
+	}
+
+	return (packedLen - 4) * FlagBits * int64(normalizeMinMatch(opts.MinMatchLength)+15) / 17
+}
+
+// ValidateBlock decodes the block at the start of src through the 4 KiB ring buffer of Reader
+// and verifies the checksum without allocating outLen bytes.
+// BlockInfo has the compressed size and checksums; a mismatch is an error only with VerifyChecksum.
+func ValidateBlock(src []byte, outLen int, opts *Options) (BlockInfo, error) {
+	if len(src) < 4 {
+		return BlockInfo{}, ErrInputTooShort
+	}
+
+	return ValidateBlockFromReader(bytes.NewReader(src), outLen, opts)
+}
+
+// ValidateBlockFromReader is like ValidateBlock for a stream, leaving r positioned after the block.
+func ValidateBlockFromReader(r io.Reader, outLen int, opts *Options) (BlockInfo, error) {
+	zr, err := NewReader(r, outLen, opts)
+	if err != nil {
+		return BlockInfo{}, err
+	}
+
+	_, err = io.Copy(io.Discard, zr)
+
+	return zr.Info(), err
+}
+
+// walkBlock reads one block from r, following flag bits and pointer lengths without producing output.
+// It reads the trailing checksum but does not verify it.
+func walkBlock(r io.ByteReader, outLen int, opts *Options) error {
+	if outLen < 0 {
+		return ErrNegativeOutLen
+	}
+
+	_, err := newTokenWalker(r, opts).walk(outLen)
+
+	return err
+}
@@ -0,0 +1,97 @@
+package lzss
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+func TestBlockSize(t *testing.T) {
+	raw := bytes.Repeat([]byte("walk only block sizing "), 200)
+	for _, opts := range []*CompressOptions{
+		nil,
+		{SearchLimit: 0},
+		{Checksum: ChecksumSigned, SearchLimit: 4096, MinMatchLength: MinMatch2},
+	} {
+		enc, err := Compress(raw, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dopts := &Options{VerifyChecksum: true, Strict: true}
+		if opts != nil {
+			dopts.Checksum = opts.Checksum
+			dopts.MinMatchLength = opts.MinMatchLength
+		}
+
+		stream := append(append([]byte{}, enc...), "trailing"...)
+		size, err := BlockSize(stream, len(raw), dopts)
+		if err != nil || size != len(enc) {
+			t.Fatalf("size=%d want=%d err=%v", size, len(enc), err)
+		}
+
+		r := bytes.NewReader(stream)
+		consumed, err := BlockSizeFromReader(onlyReader{r}, len(raw), dopts)
+		if err != nil || consumed != int64(len(enc)) || r.Len() != len("trailing") {
+			t.Fatalf("reader consumed=%d unread=%d err=%v", consumed, r.Len(), err)
+		}
+
+		info, err := ValidateBlock(stream, len(raw), dopts)
+		if err != nil || info.Consumed != int64(len(enc)) || !info.ChecksumMatch {
+			t.Fatalf("validate info=%+v err=%v", info, err)
+		}
+	}
+}
+
+func TestBlockSizeChecksum(t *testing.T) {
+	raw := []byte("checksum is walked over, not verified")
+	enc, err := Compress(raw, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc[len(enc)-1] ^= 0xFF
+
+	size, err := BlockSize(enc, len(raw), nil)
+	if err != nil || size != len(enc) {
+		t.Fatalf("size=%d err=%v", size, err)
+	}
+
+	info, err := ValidateBlock(enc, len(raw), nil)
+	var checksumErr *ChecksumError
+	if !errors.As(err, &checksumErr) || info.ChecksumMatch {
+		t.Fatalf("strict validate info=%+v err=%v", info, err)
+	}
+
+	info, err = ValidateBlock(enc, len(raw), &Options{})
+	if err != nil || info.ChecksumMatch || info.Consumed != int64(len(enc)) {
+		t.Fatalf("lenient validate info=%+v err=%v", info, err)
+	}
+
+	if _, err := BlockSize(enc[:len(enc)-2], len(raw), nil); !errors.Is(err, ErrInputTooShort) {
+		t.Fatalf("truncated: %v", err)
+	}
+}
+
+func TestMaxDecodedSize(t *testing.T) {
+	// One flag byte with 8 longest pointers (offset 1 into the filler) reaches the bound.
+	block := []byte{0x00}
+	for range FlagBits {
+		block = append(block, 0x01, 0x0F)
+	}
+	block = binary.LittleEndian.AppendUint32(block, 144*Filler)
+
+	limit := MaxDecodedSize(int64(len(block)), nil)
+	if limit != 144 {
+		t.Fatalf("limit=%d want=144", limit)
+	}
+	if _, err := Decompress(block, int(limit), nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := MaxDecodedSize(int64(len(block)), &Options{MinMatchLength: MinMatch2}); got != 136 {
+		t.Fatalf("min match 2 limit=%d want=136", got)
+	}
+	if got := MaxDecodedSize(4, nil); got != 0 {
+		t.Fatalf("checksum only limit=%d", got)
+	}
+}
@@ -0,0 +1,196 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 Maxim Levchenko (WoozyMasta)
+// Source: github.com/woozymasta/lzss
+
+package lzss
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Checkpoint binary layout (little-endian):
+//
+//	magic "LZSC" | version u8 | options u8 | reserved u16
+//	outLen u64 | pos u64 | read u64 | inOffset u64
+//	checksum u32 | flag u8 | bit u8 | reserved u16
+//	window [WindowSize]byte
+const (
+	// CheckpointMagic identifies a serialized Checkpoint.
+	CheckpointMagic = "LZSC"
+	// CheckpointVersion is the checkpoint format version written by MarshalBinary.
+	CheckpointVersion = 1
+
+	checkpointHeaderSize = 48
+	checkpointSize       = checkpointHeaderSize + WindowSize
+)
+
+// Checkpoint option bits.
+const (
+	checkpointSigned uint8 = 1 << iota
+	checkpointMinMatch2
+	checkpointVerify
+	checkpointStrict
+)
+
+// Checkpoint is a snapshot of Reader state that can be saved and resumed later
+// with NewReaderFromCheckpoint and an input reader positioned at InOffset.
+type Checkpoint struct {
+	Options  Options          // Decode options of the block.
+	OutLen   int              // Decoded size of the block.
+	Pos      int              // Bytes decoded so far.
+	Read     int              // Bytes returned by Read so far; Pos-Read bytes are pending in Window.
+	InOffset int64            // Input bytes consumed so far.
+	Checksum int32            // Running checksum over decoded bytes.
+	Flag     byte             // Current flag byte.
+	Bit      int              // Next slot in Flag; FlagBits means the next input byte is a flag byte.
+	Window   [WindowSize]byte // Ring buffer with the last decoded bytes at Pos&(WindowSize-1).
+}
+
+// Checkpoint returns the current decoding state. It fails after Close or once Read
+// returned an error (including io.EOF), since there is nothing left to resume.
+func (r *Reader) Checkpoint() (*Checkpoint, error) {
+	if r.closed {
+		return nil, ErrReaderClosed
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	opts := *r.opts
+	opts.MinMatchLength = r.minMatch
+
+	return &Checkpoint{
+		Options:  opts,
+		OutLen:   r.outLen,
+		Pos:      r.pos,
+		Read:     r.read,
+		InOffset: r.src.count,
+		Checksum: r.crc,
+		Flag:     r.flag,
+		Bit:      r.bit,
+		Window:   r.window,
+	}, nil
+}
+
+// NewReaderFromCheckpoint returns a Reader that continues decoding from cp.
+// r must be positioned at cp.InOffset of the block; Consumed and error offsets
+// continue to count from the start of the block.
+func NewReaderFromCheckpoint(r io.Reader, cp *Checkpoint) (*Reader, error) {
+	if err := cp.validate(); err != nil {
+		return nil, err
+	}
+
+	opts := cp.Options
+	zr, err := NewReader(r, cp.OutLen, &opts)
+	if err != nil {
+		return nil, err
+	}
+
+	zr.src.count = cp.InOffset
+	zr.pos = cp.Pos
+	zr.read = cp.Read
+	zr.crc = cp.Checksum
+	zr.flag = cp.Flag
+	zr.bit = cp.Bit
+	zr.window = cp.Window
+
+	return zr, nil
+}
+
+// MarshalBinary encodes the checkpoint (4144 bytes).
+func (cp *Checkpoint) MarshalBinary() ([]byte, error) {
+	if err := cp.validate(); err != nil {
+		return nil, err
+	}
+
+	var options uint8
+	if cp.Options.Checksum == ChecksumSigned {
+		options |= checkpointSigned
+	}
+	if cp.Options.MinMatchLength == MinMatch2 {
+		options |= checkpointMinMatch2
+	}
+	if cp.Options.VerifyChecksum {
+		options |= checkpointVerify
+	}
+	if cp.Options.Strict {
+		options |= checkpointStrict
+	}
+
+	buf := make([]byte, checkpointHeaderSize, checkpointSize)
+	copy(buf, CheckpointMagic)
+	buf[4] = CheckpointVersion
+	buf[5] = options
+	binary.LittleEndian.PutUint64(buf[8:], uint64(cp.OutLen))    // #nosec G115 -- validated non-negative
+	binary.LittleEndian.PutUint64(buf[16:], uint64(cp.Pos))      // #nosec G115 -- validated non-negative
+	binary.LittleEndian.PutUint64(buf[24:], uint64(cp.Read))     // #nosec G115 -- validated non-negative
+	binary.LittleEndian.PutUint64(buf[32:], uint64(cp.InOffset)) // #nosec G115 -- validated non-negative
+	binary.LittleEndian.PutUint32(buf[40:], uint32(cp.Checksum)) // #nosec G115 -- checksum bit pattern
+	buf[44] = cp.Flag
+	buf[45] = byte(cp.Bit) // #nosec G115 -- validated 0..FlagBits
+
+	return append(buf, cp.Window[:]...), nil
+}
+
+// UnmarshalBinary decodes a checkpoint written by MarshalBinary.
+func (cp *Checkpoint) UnmarshalBinary(data []byte) error {
+	if len(data) != checkpointSize {
+		return fmt.Errorf("%w: size %d", ErrInvalidCheckpoint, len(data))
+	}
+	if string(data[:4]) != CheckpointMagic {
+		return fmt.Errorf("%w: bad magic", ErrInvalidCheckpoint)
+	}
+	if data[4] != CheckpointVersion {
+		return fmt.Errorf("%w: version %d", ErrInvalidCheckpoint, data[4])
+	}
+
+	options := data[5]
+	var c Checkpoint
+	if options&checkpointSigned != 0 {
+		c.Options.Checksum = ChecksumSigned
+	}
+	c.Options.MinMatchLength = MinMatchDefault
+	if options&checkpointMinMatch2 != 0 {
+		c.Options.MinMatchLength = MinMatch2
+	}
+	c.Options.VerifyChecksum = options&checkpointVerify != 0
+	c.Options.Strict = options&checkpointStrict != 0
+
+	// Values above MaxInt64 become negative and are rejected by validate.
+	c.OutLen = int(int64(binary.LittleEndian.Uint64(data[8:]))) // #nosec G115 -- validated below
+	c.Pos = int(int64(binary.LittleEndian.Uint64(data[16:])))   // #nosec G115 -- validated below
+	c.Read = int(int64(binary.LittleEndian.Uint64(data[24:])))  // #nosec G115 -- validated below
+	c.InOffset = int64(binary.LittleEndian.Uint64(data[32:]))   // #nosec G115 -- validated below
+	c.Checksum = int32(binary.LittleEndian.Uint32(data[40:]))   // #nosec G115 -- checksum bit pattern
+	c.Flag = data[44]
+	c.Bit = int(data[45])
+	copy(c.Window[:], data[checkpointHeaderSize:])
+
+	if err := c.validate(); err != nil {
+		return err
+	}
+	*cp = c
+
+	return nil
+}
+
+// validate checks that the checkpoint describes a reachable decoder state.
+func (cp *Checkpoint) validate() error {
+	switch {
+	case cp == nil:
+		return fmt.Errorf("%w: nil", ErrInvalidCheckpoint)
+	case cp.OutLen < 0 || cp.Pos < 0 || cp.Read < 0 || cp.InOffset < 0:
+		return fmt.Errorf("%w: negative position", ErrInvalidCheckpoint)
+	case cp.Pos > cp.OutLen || cp.Read > cp.Pos || cp.Pos-cp.Read > WindowSize:
+		return fmt.Errorf("%w: pos=%d read=%d outLen=%d", ErrInvalidCheckpoint, cp.Pos, cp.Read, cp.OutLen)
+	case cp.Bit < 0 || cp.Bit > FlagBits:
+		return fmt.Errorf("%w: bit=%d", ErrInvalidCheckpoint, cp.Bit)
+	case cp.Options.MinMatchLength != 0 && cp.Options.MinMatchLength != MinMatch2 &&
+		cp.Options.MinMatchLength != MinMatchDefault:
+		return fmt.Errorf("%w: min match length %d", ErrInvalidCheckpoint, cp.Options.MinMatchLength)
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package lzss
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestCheckpointResume(t *testing.T) {
+	raw := bytes.Repeat([]byte("resumable extraction over flaky mounts; "), 600)
+	for _, opts := range []*Options{
+		nil,
+		{Checksum: ChecksumSigned, MinMatchLength: MinMatch2, VerifyChecksum: true, Strict: true},
+	} {
+		copts := &CompressOptions{SearchLimit: 4095}
+		if opts != nil {
+			copts.Checksum = opts.Checksum
+			copts.MinMatchLength = opts.MinMatchLength
+		}
+		enc, err := Compress(raw, copts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, split := range []int{1, 100, 5000, len(raw) - 1} {
+			zr, err := NewReader(bytes.NewReader(enc), len(raw), opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			head := make([]byte, split)
+			if _, err := io.ReadFull(zr, head); err != nil {
+				t.Fatal(err)
+			}
+
+			cp, err := zr.Checkpoint()
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := cp.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var restored Checkpoint
+			if err := restored.UnmarshalBinary(data); err != nil {
+				t.Fatal(err)
+			}
+
+			resumed, err := NewReaderFromCheckpoint(bytes.NewReader(enc[restored.InOffset:]), &restored)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tail, err := io.ReadAll(resumed)
+			if err != nil {
+				t.Fatalf("split=%d: %v", split, err)
+			}
+
+			if !bytes.Equal(append(head, tail...), raw) {
+				t.Fatalf("split=%d: output mismatch", split)
+			}
+			if info := resumed.Info(); info.Consumed != int64(len(enc)) || !info.ChecksumMatch {
+				t.Fatalf("split=%d: info=%+v", split, info)
+			}
+		}
+	}
+}
+
+func TestCheckpointInvalid(t *testing.T) {
+	raw := []byte("checkpoint validation")
+	enc, err := Compress(raw, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := NewReader(bytes.NewReader(enc), len(raw), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp, err := zr.Checkpoint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := cp.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, mutate := range map[string]func([]byte) []byte{
+		"short":   func(b []byte) []byte { return b[:100] },
+		"magic":   func(b []byte) []byte { b[0] = 'X'; return b },
+		"version": func(b []byte) []byte { b[4] = 9; return b },
+		"pos":     func(b []byte) []byte { b[16] = 0xFF; return b },
+		"bit":     func(b []byte) []byte { b[45] = 9; return b },
+	} {
+		var c Checkpoint
+		if err := c.UnmarshalBinary(mutate(append([]byte{}, data...))); !errors.Is(err, ErrInvalidCheckpoint) {
+			t.Fatalf("%s: %v", name, err)
+		}
+	}
+
+	bad := *cp
+	bad.Options.MinMatchLength = 4
+	if _, err := bad.MarshalBinary(); !errors.Is(err, ErrInvalidCheckpoint) {
+		t.Fatalf("marshal min match 4: %v", err)
+	}
+	if _, err := NewReaderFromCheckpoint(bytes.NewReader(enc), &bad); !errors.Is(err, ErrInvalidCheckpoint) {
+		t.Fatalf("resume min match 4: %v", err)
+	}
+
+	if _, err := io.ReadAll(zr); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := zr.Checkpoint(); !errors.Is(err, io.EOF) {
+		t.Fatalf("checkpoint after EOF: %v", err)
+	}
+}
@@ -23,3 +23,26 @@ func sumSigned(data []byte) int32 {
 
 	return s
 }
+
+// BlockInfo describes one decoded block: consumed input and checksum verification result.
+// It is filled in both strict and lenient mode, so a mismatch can be reported without failing.
+type BlockInfo struct {
+	Consumed         int64        // Consumed input bytes (data + 4-byte checksum).
+	StoredChecksum   uint32       // Checksum stored in the trailing 4 bytes of the block.
+	ComputedChecksum uint32       // Checksum computed over decoded output.
+	Mode             ChecksumMode // Checksum mode used for ComputedChecksum.
+	ChecksumMatch    bool         // StoredChecksum equals ComputedChecksum.
+}
+
+// ChecksumError returns a *ChecksumError describing the mismatch, or nil when checksums match.
+func (info BlockInfo) ChecksumError() error {
+	if info.ChecksumMatch {
+		return nil
+	}
+
+	return &ChecksumError{
+		Mode:     info.Mode,
+		Stored:   info.StoredChecksum,
+		Computed: info.ComputedChecksum,
+	}
+}
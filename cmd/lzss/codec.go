@@ -0,0 +1,204 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 Maxim Levchenko (WoozyMasta)
+// Source: github.com/woozymasta/lzss
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/woozymasta/lzss"
+)
+
+// codecFlags are options shared by compress and decompress.
+type codecFlags struct {
+	preset    string
+	minMatch  int
+	search    int
+	blockSize int
+	size      int
+	signed    bool
+	lenient   bool
+	raw       bool
+}
+
+// register adds codec flags to fs.
+func (c *codecFlags) register(fs *flag.FlagSet, compress bool) {
+	fs.BoolVar(&c.raw, "raw", false, "bare LZSS:8bit block without frame")
+	fs.StringVar(&c.preset, "preset", "", "named options preset ("+presetList()+"); explicit flags override it")
+	fs.BoolVar(&c.signed, "signed", false, "signed checksum (raw mode or compress)")
+	fs.IntVar(&c.minMatch, "min-match", lzss.MinMatchDefault, "minimum match length: 3 or 2 (raw mode or compress)")
+
+	if compress {
+		fs.IntVar(&c.search, "search", lzss.DefaultCompressOptions().SearchLimit, "match search limit (0 = literals only, max 4095)")
+		fs.IntVar(&c.blockSize, "block-size", lzss.DefaultFrameBlockSize, "decoded size of one frame block")
+		return
+	}
+
+	fs.IntVar(&c.size, "size", -1, "decoded size (required with -raw)")
+	fs.BoolVar(&c.lenient, "lenient", false, "ignore checksum mismatch (raw mode)")
+}
+
+// resolve applies -preset to flags not set on the command line and validates values.
+func (c *codecFlags) resolve(fs *flag.FlagSet) error {
+	if c.preset != "" {
+		opts, copts, err := lzss.Preset(c.preset)
+		if err != nil {
+			return fmt.Errorf("%w: %w", errUsage, err)
+		}
+
+		set := make(map[string]bool)
+		fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+		if !set["signed"] {
+			c.signed = opts.Checksum == lzss.ChecksumSigned
+		}
+		if !set["min-match"] {
+			c.minMatch = opts.MinMatchLength
+		}
+		if !set["lenient"] {
+			c.lenient = !opts.VerifyChecksum
+		}
+		if !set["search"] {
+			c.search = copts.SearchLimit
+		}
+	}
+
+	if c.minMatch != lzss.MinMatchDefault && c.minMatch != lzss.MinMatch2 {
+		return fmt.Errorf("%w: -min-match must be 2 or 3", errUsage)
+	}
+
+	return nil
+}
+
+// presetList returns registered preset names for flag usage.
+func presetList() string {
+	list := lzss.Presets()
+	names := make([]string, 0, len(list))
+	for _, d := range list {
+		names = append(names, d.Name)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// checksum returns the selected checksum mode.
+func (c *codecFlags) checksum() lzss.ChecksumMode {
+	if c.signed {
+		return lzss.ChecksumSigned
+	}
+
+	return lzss.ChecksumUnsigned
+}
+
+// compressOptions returns compression options from flags.
+func (c *codecFlags) compressOptions() *lzss.CompressOptions {
+	return &lzss.CompressOptions{
+		Checksum:       c.checksum(),
+		SearchLimit:    c.search,
+		MinMatchLength: c.minMatch,
+	}
+}
+
+// options returns decode options from flags.
+func (c *codecFlags) options() *lzss.Options {
+	return &lzss.Options{
+		Checksum:       c.checksum(),
+		VerifyChecksum: !c.lenient,
+		MinMatchLength: c.minMatch,
+	}
+}
+
+// runCompress implements "lzss compress".
+func runCompress(e *env, args []string) error {
+	var c codecFlags
+	fs := newFlagSet(e, "compress")
+	c.register(fs, true)
+
+	input, output, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.resolve(fs); err != nil {
+		return err
+	}
+
+	data, err := readInput(e, input)
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(e, output, func(w io.Writer) error {
+		if c.raw {
+			enc, err := lzss.Compress(data, c.compressOptions())
+			if err != nil {
+				return err
+			}
+			_, err = w.Write(enc)
+
+			return err
+		}
+
+		fw := lzss.NewFrameWriter(w, c.compressOptions())
+		fw.BlockSize = c.blockSize
+		if _, err := fw.Write(data); err != nil {
+			return err
+		}
+
+		return fw.Close()
+	})
+}
+
+// runDecompress implements "lzss decompress".
+func runDecompress(e *env, args []string) error {
+	var c codecFlags
+	fs := newFlagSet(e, "decompress")
+	c.register(fs, false)
+
+	input, output, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.resolve(fs); err != nil {
+		return err
+	}
+	if c.raw && c.size < 0 {
+		return fmt.Errorf("%w: -raw requires -size", errUsage)
+	}
+
+	if c.raw {
+		data, err := readInput(e, input)
+		if err != nil {
+			return err
+		}
+
+		out, err := lzss.Decompress(data, c.size, c.options())
+		if err != nil {
+			return err
+		}
+
+		return writeOutput(e, output, func(w io.Writer) error {
+			_, err := w.Write(out)
+			return err
+		})
+	}
+
+	in, err := openInput(e, input)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+
+	fr, err := lzss.NewFrameReader(in)
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(e, output, func(w io.Writer) error {
+		_, err := io.Copy(w, fr)
+		return err
+	})
+}
@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 Maxim Levchenko (WoozyMasta)
+// Source: github.com/woozymasta/lzss
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/woozymasta/lzss"
+)
+
+// runInfo implements "lzss info": prints the frame header without decoding blocks.
+func runInfo(e *env, args []string) error {
+	fs := newFlagSet(e, "info")
+	input, output, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if output != "-" {
+		return fmt.Errorf("%w: info takes one input", errUsage)
+	}
+
+	in, err := openInput(e, input)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+
+	header, err := lzss.ReadFrameHeader(in)
+	if err != nil {
+		return err
+	}
+
+	opts := header.Options()
+	var packed uint64
+	for _, block := range header.Blocks {
+		packed += uint64(block.PackedSize)
+	}
+
+	presets := strings.Join(lzss.PresetNames(opts), ", ")
+	if presets == "" {
+		presets = "-"
+	}
+
+	_, err = fmt.Fprintf(e.stdout,
+		"version:   %d\nsize:      %d\npacked:    %d\nblocks:    %d\nchecksum:  %s\nmin match: %d\npresets:   %s\n",
+		header.Version, header.Size, packed, len(header.Blocks), checksumName(opts.Checksum), minMatch(opts.MinMatchLength), presets)
+
+	return err
+}
+
+// checksumName returns a readable checksum mode.
+func checksumName(mode lzss.ChecksumMode) string {
+	if mode == lzss.ChecksumSigned {
+		return "signed"
+	}
+
+	return "unsigned"
+}
@@ -0,0 +1,185 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 Maxim Levchenko (WoozyMasta)
+// Source: github.com/woozymasta/lzss
+
+/*
+Command lzss compresses and decompresses LZSS:8bit data.
+
+Usage:
+
+	lzss compress [flags] [input [output]]
+	lzss decompress [flags] [input [output]]
+	lzss stats [input]
+	lzss info [input]
+	lzss scan [flags] [input]
+	lzss recompress -size n [flags] [input [output]]
+
+Input and output default to stdin and stdout ("-").
+The framed format (lzss.NewFrameWriter) is used by default; it stores the original
+size and options, so decompress needs no side information. Use -raw to write or
+read a bare LZSS:8bit block; raw decompress requires -size.
+Use -preset (pbo, paa, mm2 or a registered name) to select a format dialect;
+explicit flags override preset values. Info prints a frame header and the
+presets matching its options. Scan searches any file for embedded LZSS blocks
+(lzss.Scan) and prints their offsets, sizes and matching presets.
+Recompress re-encodes a bare block with other options (-from and -to presets),
+reports the size delta and refuses to write a larger block unless -force is set.
+*/
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// env holds standard streams for commands.
+type env struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// command is a subcommand entry point.
+type command struct {
+	run   func(e *env, args []string) error
+	usage string
+}
+
+// commands lists subcommands by name.
+var commands = map[string]command{
+	"compress":   {run: runCompress, usage: "compress data (framed by default)"},
+	"decompress": {run: runDecompress, usage: "decompress data (framed by default)"},
+	"info":       {run: runInfo, usage: "print frame header and matching presets"},
+	"recompress": {run: runRecompress, usage: "re-encode a bare block with other options"},
+	"scan":       {run: runScan, usage: "find embedded LZSS blocks in a file"},
+	"stats":      {run: runStats, usage: "compare compression statistics across compression levels"},
+}
+
+// errUsage is returned for invalid command line usage.
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	os.Exit(run(os.Args[1:], &env{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr}))
+}
+
+// run executes the subcommand in args and returns the process exit code.
+func run(args []string, e *env) int {
+	if len(args) == 0 {
+		printUsage(e.stderr)
+		return 2
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		if args[0] != "-h" && args[0] != "-help" && args[0] != "help" {
+			_, _ = fmt.Fprintf(e.stderr, "lzss: unknown command %q\n", args[0])
+		}
+		printUsage(e.stderr)
+		return 2
+	}
+
+	if err := cmd.run(e, args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+
+		_, _ = fmt.Fprintf(e.stderr, "lzss %s: %v\n", args[0], err)
+		if errors.Is(err, errUsage) {
+			return 2
+		}
+
+		return 1
+	}
+
+	return 0
+}
+
+// printUsage prints the command list.
+func printUsage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	_, _ = fmt.Fprintln(w, "usage: lzss <command> [flags] [input [output]]")
+	_, _ = fmt.Fprintln(w, "commands:")
+	for _, name := range names {
+		_, _ = fmt.Fprintf(w, "  %-12s %s\n", name, commands[name].usage)
+	}
+}
+
+// newFlagSet returns a flag set that reports errors instead of exiting.
+func newFlagSet(e *env, name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("lzss "+name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+
+	return fs
+}
+
+// parseArgs parses flags and returns input and output paths ("-" for stdio).
+func parseArgs(fs *flag.FlagSet, args []string) (string, string, error) {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return "", "", err
+		}
+
+		return "", "", fmt.Errorf("%w: %w", errUsage, err)
+	}
+
+	input, output := "-", "-"
+	switch fs.NArg() {
+	case 0:
+	case 1:
+		input = fs.Arg(0)
+	case 2:
+		input, output = fs.Arg(0), fs.Arg(1)
+	default:
+		return "", "", fmt.Errorf("%w: too many arguments", errUsage)
+	}
+
+	return input, output, nil
+}
+
+// readInput reads the whole input file or stdin for "-".
+func readInput(e *env, name string) ([]byte, error) {
+	if name == "-" {
+		return io.ReadAll(e.stdin)
+	}
+
+	return os.ReadFile(name) // #nosec G304 -- user provided path
+}
+
+// openInput opens the input file or stdin for "-".
+func openInput(e *env, name string) (io.ReadCloser, error) {
+	if name == "-" {
+		return io.NopCloser(e.stdin), nil
+	}
+
+	return os.Open(name) // #nosec G304 -- user provided path
+}
+
+// writeOutput writes data produced by fn to the output file or stdout for "-".
+// A partially written output file is removed on error.
+func writeOutput(e *env, name string, fn func(w io.Writer) error) error {
+	if name == "-" {
+		return fn(e.stdout)
+	}
+
+	f, err := os.Create(name) // #nosec G304 -- user provided path
+	if err != nil {
+		return err
+	}
+
+	if err := fn(f); err != nil {
+		_ = f.Close()
+		_ = os.Remove(name)
+		return err
+	}
+
+	return f.Close()
+}
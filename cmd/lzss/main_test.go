@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/woozymasta/lzss"
+)
+
+// runCLI runs the command with stdin and returns stdout, stderr and exit code.
+func runCLI(t *testing.T, stdin []byte, args ...string) ([]byte, string, int) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	code := run(args, &env{stdin: bytes.NewReader(stdin), stdout: &stdout, stderr: &stderr})
+
+	return stdout.Bytes(), stderr.String(), code
+}
+
+func TestCompressDecompressFramed(t *testing.T) {
+	data := bytes.Repeat([]byte("cli round trip "), 500)
+	dir := t.TempDir()
+	in := filepath.Join(dir, "in.bin")
+	packed := filepath.Join(dir, "in.lzss")
+	if err := os.WriteFile(in, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, stderr, code := runCLI(t, nil, "compress", "-block-size", "1024", in, packed); code != 0 {
+		t.Fatalf("compress: code=%d stderr=%s", code, stderr)
+	}
+	frame, err := os.ReadFile(packed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(frame, []byte(lzss.FrameMagic)) {
+		t.Fatal("output is not framed")
+	}
+
+	out, stderr, code := runCLI(t, frame, "decompress")
+	if code != 0 {
+		t.Fatalf("decompress: code=%d stderr=%s", code, stderr)
+	}
+	if !bytes.Equal(out, data) {
+		t.Fatal("round-trip mismatch")
+	}
+}
+
+func TestCompressDecompressRaw(t *testing.T) {
+	data := []byte("raw block via cli, raw block via cli")
+	enc, stderr, code := runCLI(t, data, "compress", "-raw", "-signed", "-min-match", "2")
+	if code != 0 {
+		t.Fatalf("compress: code=%d stderr=%s", code, stderr)
+	}
+
+	dec, err := lzss.Decompress(enc, len(data), &lzss.Options{Checksum: lzss.ChecksumSigned, VerifyChecksum: true, MinMatchLength: lzss.MinMatch2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(dec, data) {
+		t.Fatal("raw block mismatch")
+	}
+
+	if _, stderr, code := runCLI(t, enc, "decompress", "-raw"); code != 2 || !strings.Contains(stderr, "-size") {
+		t.Fatalf("want usage error, code=%d stderr=%s", code, stderr)
+	}
+
+	out, stderr, code := runCLI(t, enc, "decompress", "-raw", "-signed", "-min-match", "2", "-size", "36")
+	if code != 0 {
+		t.Fatalf("decompress: code=%d stderr=%s", code, stderr)
+	}
+	if !bytes.Equal(out, data) {
+		t.Fatal("round-trip mismatch")
+	}
+}
+
+func TestUnknownCommand(t *testing.T) {
+	if _, stderr, code := runCLI(t, nil, "bogus"); code != 2 || !strings.Contains(stderr, "unknown command") {
+		t.Fatalf("code=%d stderr=%s", code, stderr)
+	}
+}
+
+func TestStats(t *testing.T) {
+	data := bytes.Repeat([]byte("stats across levels "), 100)
+	out, stderr, code := runCLI(t, data, "stats")
+	if code != 0 {
+		t.Fatalf("code=%d stderr=%s", code, stderr)
+	}
+
+	text := string(out)
+	for _, l := range statsLevels {
+		if !strings.Contains(text, l.name) {
+			t.Fatalf("missing level %q in output:\n%s", l.name, text)
+		}
+	}
+	if !strings.Contains(text, "window use") || !strings.Contains(text, "length: count/saved") {
+		t.Fatalf("unexpected output:\n%s", text)
+	}
+}
+
+func TestPreset(t *testing.T) {
+	data := bytes.Repeat([]byte("preset dialect "), 200)
+
+	enc, stderr, code := runCLI(t, data, "compress", "-raw", "-preset", "paa")
+	if code != 0 {
+		t.Fatalf("compress: code=%d stderr=%s", code, stderr)
+	}
+	if _, err := lzss.Decompress(enc, len(data), &lzss.Options{Checksum: lzss.ChecksumSigned, VerifyChecksum: true}); err != nil {
+		t.Fatalf("paa block: %v", err)
+	}
+
+	// Explicit flags override the preset.
+	enc, stderr, code = runCLI(t, data, "compress", "-raw", "-preset", "paa", "-signed=false", "-min-match", "2")
+	if code != 0 {
+		t.Fatalf("compress: code=%d stderr=%s", code, stderr)
+	}
+	if _, err := lzss.Decompress(enc, len(data), &lzss.Options{VerifyChecksum: true, MinMatchLength: lzss.MinMatch2}); err != nil {
+		t.Fatalf("override block: %v", err)
+	}
+
+	if _, stderr, code := runCLI(t, data, "compress", "-preset", "nope"); code != 2 || !strings.Contains(stderr, "unknown lzss preset") {
+		t.Fatalf("unknown preset: code=%d stderr=%s", code, stderr)
+	}
+}
+
+func TestInfo(t *testing.T) {
+	data := bytes.Repeat([]byte("frame info "), 300)
+	frame, stderr, code := runCLI(t, data, "compress", "-preset", "paa", "-block-size", "1024")
+	if code != 0 {
+		t.Fatalf("compress: code=%d stderr=%s", code, stderr)
+	}
+
+	out, stderr, code := runCLI(t, frame, "info")
+	if code != 0 {
+		t.Fatalf("info: code=%d stderr=%s", code, stderr)
+	}
+
+	text := string(out)
+	for _, want := range []string{"size:      3300", "blocks:    4", "checksum:  signed", "presets:   paa"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("missing %q in output:\n%s", want, text)
+		}
+	}
+}
+
+func TestScan(t *testing.T) {
+	raw := bytes.Repeat([]byte("embedded block \x80\x81 "), 40)
+	enc, err := lzss.Compress(raw, &lzss.CompressOptions{Checksum: lzss.ChecksumSigned, SearchLimit: 2048})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := append(bytes.Repeat([]byte{0xAA}, 64), byte(len(raw)), byte(len(raw)>>8), 0, 0)
+	data = append(data, enc...)
+	data = append(data, bytes.Repeat([]byte{0xAA}, 64)...)
+
+	for _, args := range [][]string{{"scan"}, {"scan", "-brute"}, {"scan", "-preset", "paa"}} {
+		out, stderr, code := runCLI(t, data, args...)
+		if code != 0 {
+			t.Fatalf("%v: code=%d stderr=%s", args, code, stderr)
+		}
+
+		lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("%v: unexpected output:\n%s", args, out)
+		}
+		fields := strings.Fields(lines[1])
+		want := []string{"0x00000044", strconv.Itoa(len(raw)), strconv.Itoa(len(enc))}
+		if len(fields) != 5 || !slices.Equal(fields[:3], want) || fields[4] != "paa" {
+			t.Fatalf("%v: row %q, want %v ... paa", args, lines[1], want)
+		}
+	}
+
+	// Wrong preset finds nothing.
+	out, _, code := runCLI(t, data, "scan", "-preset", "mm2")
+	if code != 0 || strings.Count(string(out), "\n") != 1 {
+		t.Fatalf("mm2: code=%d output:\n%s", code, out)
+	}
+}
+
+func TestRecompress(t *testing.T) {
+	data := bytes.Repeat([]byte("recompress via cli \xa0 "), 50)
+	literals, err := lzss.Compress(data, &lzss.CompressOptions{Checksum: lzss.ChecksumSigned})
+	if err != nil {
+		t.Fatal(err)
+	}
+	size := strconv.Itoa(len(data))
+
+	out, stderr, code := runCLI(t, literals, "recompress", "-size", size, "-from", "paa", "-to", "pbo")
+	if code != 0 {
+		t.Fatalf("recompress: code=%d stderr=%s", code, stderr)
+	}
+	if !strings.Contains(stderr, " -> ") || len(out) >= len(literals) {
+		t.Fatalf("stderr=%q size=%d", stderr, len(out))
+	}
+	if dec, err := lzss.Decompress(out, len(data), nil); err != nil || !bytes.Equal(dec, data) {
+		t.Fatalf("decode err=%v", err)
+	}
+
+	// Back to literals only is larger: refused unless forced.
+	larger := []string{"recompress", "-size", size, "-search", "0", "-signed"}
+	if out, stderr, code := runCLI(t, out, larger...); code != 1 || len(out) != 0 || !strings.Contains(stderr, "-force") {
+		t.Fatalf("larger: code=%d stderr=%s", code, stderr)
+	}
+	forced, stderr, code := runCLI(t, out, append(larger, "-force")...)
+	if code != 0 || !bytes.Equal(forced, literals) {
+		t.Fatalf("forced: code=%d stderr=%s", code, stderr)
+	}
+
+	if _, stderr, code := runCLI(t, literals, "recompress", "-from", "paa"); code != 2 || !strings.Contains(stderr, "-size") {
+		t.Fatalf("missing size: code=%d stderr=%s", code, stderr)
+	}
+}
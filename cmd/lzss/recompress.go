@@ -0,0 +1,151 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 Maxim Levchenko (WoozyMasta)
+// Source: github.com/woozymasta/lzss
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/woozymasta/lzss"
+)
+
+// errLarger is returned when recompression does not shrink the block and -force is not set.
+var errLarger = errors.New("recompressed block is larger; use -force to write it")
+
+// recompressFlags are input and output dialect flags of "lzss recompress".
+type recompressFlags struct {
+	from       string
+	to         string
+	inMinMatch int
+	minMatch   int
+	search     int
+	size       int
+	inSigned   bool
+	lenient    bool
+	signed     bool
+	force      bool
+}
+
+// register adds recompress flags to fs.
+func (c *recompressFlags) register(fs *flag.FlagSet) {
+	fs.IntVar(&c.size, "size", -1, "decoded size of the block (required)")
+	fs.StringVar(&c.from, "from", "", "input preset ("+presetList()+"); -in-* flags override it")
+	fs.BoolVar(&c.inSigned, "in-signed", false, "input has signed checksum")
+	fs.IntVar(&c.inMinMatch, "in-min-match", lzss.MinMatchDefault, "input minimum match length: 3 or 2")
+	fs.BoolVar(&c.lenient, "lenient", false, "ignore input checksum mismatch")
+	fs.StringVar(&c.to, "to", "", "output preset ("+presetList()+"); explicit flags override it")
+	fs.BoolVar(&c.signed, "signed", false, "write signed checksum")
+	fs.IntVar(&c.minMatch, "min-match", lzss.MinMatchDefault, "output minimum match length: 3 or 2")
+	fs.IntVar(&c.search, "search", lzss.DefaultCompressOptions().SearchLimit, "match search limit (0 = literals only, max 4095)")
+	fs.BoolVar(&c.force, "force", false, "write output even if it is larger than input")
+}
+
+// resolve applies -from and -to to flags not set on the command line and validates values.
+func (c *recompressFlags) resolve(fs *flag.FlagSet) error {
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	if c.from != "" {
+		opts, _, err := lzss.Preset(c.from)
+		if err != nil {
+			return fmt.Errorf("%w: %w", errUsage, err)
+		}
+		if !set["in-signed"] {
+			c.inSigned = opts.Checksum == lzss.ChecksumSigned
+		}
+		if !set["in-min-match"] {
+			c.inMinMatch = minMatch(opts.MinMatchLength)
+		}
+		if !set["lenient"] {
+			c.lenient = !opts.VerifyChecksum
+		}
+	}
+
+	if c.to != "" {
+		_, copts, err := lzss.Preset(c.to)
+		if err != nil {
+			return fmt.Errorf("%w: %w", errUsage, err)
+		}
+		if !set["signed"] {
+			c.signed = copts.Checksum == lzss.ChecksumSigned
+		}
+		if !set["min-match"] {
+			c.minMatch = minMatch(copts.MinMatchLength)
+		}
+		if !set["search"] {
+			c.search = copts.SearchLimit
+		}
+	}
+
+	for _, n := range []int{c.inMinMatch, c.minMatch} {
+		if n != lzss.MinMatchDefault && n != lzss.MinMatch2 {
+			return fmt.Errorf("%w: -min-match and -in-min-match must be 2 or 3", errUsage)
+		}
+	}
+	if c.size < 0 {
+		return fmt.Errorf("%w: -size is required", errUsage)
+	}
+
+	return nil
+}
+
+// options returns decode options of the input block.
+func (c *recompressFlags) options() *lzss.Options {
+	opts := &lzss.Options{VerifyChecksum: !c.lenient, MinMatchLength: c.inMinMatch}
+	if c.inSigned {
+		opts.Checksum = lzss.ChecksumSigned
+	}
+
+	return opts
+}
+
+// compressOptions returns encode options of the output block.
+func (c *recompressFlags) compressOptions() *lzss.CompressOptions {
+	opts := &lzss.CompressOptions{SearchLimit: c.search, MinMatchLength: c.minMatch}
+	if c.signed {
+		opts.Checksum = lzss.ChecksumSigned
+	}
+
+	return opts
+}
+
+// runRecompress implements "lzss recompress": re-encodes a bare block with other options.
+func runRecompress(e *env, args []string) error {
+	fs := newFlagSet(e, "recompress")
+	var c recompressFlags
+	c.register(fs)
+
+	input, output, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.resolve(fs); err != nil {
+		return err
+	}
+
+	src, err := readInput(e, input)
+	if err != nil {
+		return err
+	}
+
+	enc, err := lzss.Recompress(src, c.size, c.options(), c.compressOptions())
+	if err != nil {
+		return err
+	}
+
+	delta := len(enc) - len(src)
+	_, _ = fmt.Fprintf(e.stderr, "%d -> %d bytes (%+d)\n", len(src), len(enc), delta)
+	if delta > 0 && !c.force {
+		return errLarger
+	}
+
+	return writeOutput(e, output, func(w io.Writer) error {
+		_, err := w.Write(enc)
+
+		return err
+	})
+}
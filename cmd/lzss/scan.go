@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 Maxim Levchenko (WoozyMasta)
+// Source: github.com/woozymasta/lzss
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/woozymasta/lzss"
+)
+
+// runScan implements "lzss scan": searches a file for embedded LZSS blocks.
+func runScan(e *env, args []string) error {
+	fs := newFlagSet(e, "scan")
+	var (
+		opts   lzss.ScanOptions
+		preset string
+		brute  bool
+		filler bool
+	)
+	fs.BoolVar(&brute, "brute", false, "find block ends by checksum only instead of 32-bit size fields")
+	fs.StringVar(&preset, "preset", "", "try only this preset ("+presetList()+"); default all")
+	fs.BoolVar(&filler, "filler", false, "accept back-references into the filler region (much slower)")
+	fs.IntVar(&opts.MinOutLen, "min-size", 16, "smallest decoded size reported")
+	fs.IntVar(&opts.MaxOutLen, "max-size", 1<<20, "largest decoded size tried")
+	fs.IntVar(&opts.SizeFieldRange, "field-range", 16, "bytes before an offset searched for size fields")
+	fs.BoolVar(&opts.Overlapping, "overlap", false, "also report blocks starting inside found blocks")
+
+	input, output, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if output != "-" {
+		return fmt.Errorf("%w: scan takes one input", errUsage)
+	}
+
+	dialects := lzss.Presets()
+	if preset != "" {
+		d, ok := lzss.LookupPreset(preset)
+		if !ok {
+			return fmt.Errorf("%w: %w: %q", errUsage, lzss.ErrUnknownPreset, preset)
+		}
+		dialects = []lzss.Dialect{d}
+	}
+	for _, d := range dialects {
+		o := lzss.Options{
+			Checksum:       d.Options.Checksum,
+			VerifyChecksum: true,
+			MinMatchLength: minMatch(d.Options.MinMatchLength),
+			Strict:         !filler,
+		}
+		if !slices.Contains(opts.Options, o) {
+			opts.Options = append(opts.Options, o)
+		}
+	}
+	if brute {
+		opts.Mode = lzss.ScanBruteForce
+	}
+
+	data, err := readInput(e, input)
+	if err != nil {
+		return err
+	}
+
+	return printScan(e.stdout, lzss.Scan(data, opts))
+}
+
+// printScan writes one row per found block.
+func printScan(w io.Writer, results []lzss.ScanResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "offset\tsize\tpacked\tfield\tpresets")
+	for _, res := range results {
+		field := "-"
+		if res.SizeField >= 0 {
+			field = fmt.Sprintf("0x%08x", res.SizeField)
+		}
+		presets := strings.Join(res.Presets, ",")
+		if presets == "" {
+			presets = "-"
+		}
+
+		_, _ = fmt.Fprintf(tw, "0x%08x\t%d\t%d\t%s\t%s\n", res.Offset, res.OutLen, res.Consumed, field, presets)
+	}
+
+	return tw.Flush()
+}
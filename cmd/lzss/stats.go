@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 Maxim Levchenko (WoozyMasta)
+// Source: github.com/woozymasta/lzss
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/woozymasta/lzss"
+)
+
+// statsLevel is a named compression level compared by "lzss stats".
+// Levels vary search limit and min match; they are not dialect presets (lzss.Presets).
+type statsLevel struct {
+	opts *lzss.CompressOptions
+	name string
+}
+
+// statsLevels are compared side by side.
+var statsLevels = []statsLevel{
+	{name: "literals", opts: &lzss.CompressOptions{SearchLimit: 0}},
+	{name: "fast", opts: &lzss.CompressOptions{SearchLimit: 256}},
+	{name: "default", opts: lzss.DefaultCompressOptions()},
+	{name: "max", opts: &lzss.CompressOptions{SearchLimit: lzss.WindowSize - 1}},
+	{name: "max-mm2", opts: &lzss.CompressOptions{SearchLimit: lzss.WindowSize - 1, MinMatchLength: lzss.MinMatch2}},
+}
+
+// runStats implements "lzss stats".
+func runStats(e *env, args []string) error {
+	fs := newFlagSet(e, "stats")
+	input, output, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if output != "-" {
+		return fmt.Errorf("%w: stats takes one input", errUsage)
+	}
+
+	data, err := readInput(e, input)
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return lzss.ErrEmptyInput
+	}
+
+	stats := make([]lzss.Stats, len(statsLevels))
+	for i, l := range statsLevels {
+		stats[i] = lzss.Analyze(data, l.opts)
+	}
+
+	return printStats(e.stdout, statsLevels, stats)
+}
+
+// printStats writes a side-by-side table of stats, one column per level.
+func printStats(w io.Writer, levels []statsLevel, stats []lzss.Stats) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+
+	row := func(label string, value func(st *lzss.Stats, p statsLevel) string) {
+		_, _ = fmt.Fprint(tw, label, "\t")
+		for i := range stats {
+			_, _ = fmt.Fprint(tw, value(&stats[i], levels[i]), "\t")
+		}
+		_, _ = fmt.Fprintln(tw)
+	}
+	intRow := func(label string, value func(st *lzss.Stats) int) {
+		row(label, func(st *lzss.Stats, _ statsLevel) string { return fmt.Sprint(value(st)) })
+	}
+
+	row("level", func(_ *lzss.Stats, l statsLevel) string { return l.name })
+	row("search limit", func(_ *lzss.Stats, l statsLevel) string { return fmt.Sprint(l.opts.SearchLimit) })
+	row("min match", func(_ *lzss.Stats, l statsLevel) string { return fmt.Sprint(minMatch(l.opts.MinMatchLength)) })
+	intRow("input", func(st *lzss.Stats) int { return st.InputSize })
+	intRow("output", func(st *lzss.Stats) int { return st.OutputSize })
+	row("ratio", func(st *lzss.Stats, _ statsLevel) string { return fmt.Sprintf("%.3f", st.Ratio()) })
+	intRow("literals", func(st *lzss.Stats) int { return st.Literals })
+	intRow("pointers", func(st *lzss.Stats) int { return st.Pointers })
+	intRow("flag bytes", func(st *lzss.Stats) int { return st.FlagBytes })
+	intRow("saved", func(st *lzss.Stats) int { return st.Saved() })
+	intRow("max offset", func(st *lzss.Stats) int { return st.MaxOffset })
+	row("mean offset", func(st *lzss.Stats, _ statsLevel) string { return fmt.Sprintf("%.0f", st.MeanOffset()) })
+	row("window use", func(st *lzss.Stats, _ statsLevel) string { return fmt.Sprintf("%.2f", st.WindowUse()) })
+
+	row("length: count/saved", func(*lzss.Stats, statsLevel) string { return "" })
+	for length := lzss.MinMatch2; length <= lzss.MaxMatch; length++ {
+		row(fmt.Sprintf("%d", length), func(st *lzss.Stats, _ statsLevel) string {
+			return fmt.Sprintf("%d/%d", st.MatchLengths[length], st.SavedByLength[length])
+		})
+	}
+	row("offset: count", func(*lzss.Stats, statsLevel) string { return "" })
+	for bucket := range lzss.OffsetBuckets {
+		row(fmt.Sprintf("%d-%d", 1<<bucket, 1<<(bucket+1)-1), func(st *lzss.Stats, _ statsLevel) string {
+			return fmt.Sprint(st.Offsets[bucket])
+		})
+	}
+
+	return tw.Flush()
+}
+
+// minMatch returns the effective minimum match length (zero means lzss.MinMatchDefault).
+func minMatch(n int) int {
+	if n == 0 {
+		return lzss.MinMatchDefault
+	}
+
+	return n
+}
@@ -6,13 +6,14 @@ package lzss
 
 import (
 	"encoding/binary"
+	"math/bits"
 )
 
 // CompressOptions configures compression (checksum mode and search limit).
 type CompressOptions struct {
 	// Checksum mode: unsigned or signed.
 	Checksum ChecksumMode
-	// 0 = literals only; otherwise max backward distance for match search (e.g. 64..4096).
+	// 0 = literals only; otherwise max backward distance for match search (e.g. 64..4095; larger values are capped).
 	SearchLimit int
 	// MinMatchLength: 3 (default) encodes length 3..18; 2 encodes 2..17. Zero is 3.
 	MinMatchLength int
@@ -35,148 +36,162 @@ func Compress(src []byte, opts *CompressOptions) ([]byte, error) {
 		return nil, ErrEmptyInput
 	}
 
-	signed := opts.Checksum == ChecksumSigned
-	var crc int32
-	if signed {
-		crc = sumSigned(src)
-	} else {
-		crc = sumUnsigned(src)
-	}
-
 	// Pre-allocate: worst case is all literals + flag bytes + 4 crc; slight overestimate.
 	bufCap := len(src) + (len(src)+7)/8 + 4 + 64
-	out := make([]byte, 0, bufCap)
-	outData := make([]byte, 0, len(src)) // Reconstructed output so far; used as search window for matches.
+	e := newEncoder(opts, make([]byte, 0, bufCap))
+	e.encode(src, 0, len(src))
+	e.finish(e.checksum(src))
 
-	var flagByte byte
-	bitCount := 0
-	flagPos := -1
+	return e.out, nil
+}
 
-	writeFlags := func() {
-		if flagPos >= 0 {
-			out[flagPos] = flagByte
-		}
-		flagByte = 0
-		bitCount = 0
-	}
-	startChunk := func() {
-		flagPos = len(out)
-		out = append(out, 0)
-	}
+// encoder emits tokens of one block; Compress and CompressToWriter share it.
+type encoder struct {
+	out      []byte // encoded output not yet handed out
+	flagPos  int    // position of the open flag byte in out
+	flagByte byte
+	bitCount int // tokens under the open flag byte; 0 means none is open
+
+	signed   bool
+	minMatch int
+	limit    int // max match offset; 0 means literals only
+}
 
-	startChunk()
+// newEncoder returns an encoder for opts (not nil) appending to out.
+func newEncoder(opts *CompressOptions, out []byte) *encoder {
 	minMatch := opts.MinMatchLength
 	if minMatch == 0 {
 		minMatch = MinMatchDefault
 	}
 
-	// If search limit is 0, we don't need to search for matches.
-	limit := opts.SearchLimit
-	if limit <= 0 {
-		// Fast path for literals only (no match search window needed).
-		for i := range src {
-			flagByte |= 1 << bitCount
-			out = append(out, src[i])
-
-			bitCount++
-			if bitCount == FlagBits {
-				writeFlags()
-				if i+1 < len(src) {
-					startChunk()
-				}
-			}
-		}
-
-		if bitCount > 0 {
-			writeFlags()
-		}
+	// Offsets are 12-bit: 4096 would encode as 0.
+	limit := min(max(opts.SearchLimit, 0), WindowSize-1)
 
-		buf := make([]byte, 4)
-		binary.LittleEndian.PutUint32(buf, uint32(crc)) // #nosec G115 -- store checksum bit pattern
-		out = append(out, buf...)
-
-		return out, nil
+	return &encoder{
+		out:      out,
+		signed:   opts.Checksum == ChecksumSigned,
+		minMatch: minMatch,
+		limit:    limit,
 	}
+}
 
-	if limit > WindowSize {
-		limit = WindowSize
+// checksum returns the checksum of src in the encoder's mode; sums of chunks add up.
+func (e *encoder) checksum(src []byte) int32 {
+	if e.signed {
+		return sumSigned(src)
 	}
 
-	i := 0
-	for i < len(src) {
+	return sumUnsigned(src)
+}
+
+// encode emits tokens for positions of src from i until stop and returns the next position,
+// which may pass stop by the last match. Matches look back at most e.limit bytes
+// (src must hold them before i) and forward up to the end of src.
+func (e *encoder) encode(src []byte, i, stop int) int {
+	maxEncLen := e.minMatch + 15
+	for i < stop {
 		bestLen := 0
 		bestOff := 0
 
-		// Find longest match in outData (search window) within limit bytes back.
-		if limit > 0 {
-			maxCheck := min(min(len(outData), WindowSize), limit)
-
-			for off := 1; off <= maxCheck; off++ {
-				length := 0
-				checkIdx := i + length
-				refIdx := len(outData) - off + length
-				for length < MaxMatch && checkIdx < len(src) {
-					if refIdx < 0 || refIdx >= len(outData) {
-						break
-					}
-
-					if outData[refIdx] != src[checkIdx] {
-						break
-					}
-
-					length++
-					checkIdx++
-					refIdx++
-				}
+		// Find longest match within limit bytes back. Decoded output equals src, so matches
+		// are compared against src directly and may extend past i (offset < length),
+		// which the decoder resolves byte by byte (run-length style).
+		maxCheck := min(i, e.limit)
+		maxLen := min(maxEncLen, len(src)-i)
+		cur := src[i : i+maxLen]
+		for off := 1; off <= maxCheck; off++ {
+			// A candidate can only win if it also matches at bestLen; check that byte first.
+			ref := src[i-off : i-off+maxLen]
+			if ref[bestLen] != cur[bestLen] {
+				continue
+			}
+
+			length := matchLen(ref, cur)
 
-				if length > bestLen {
-					bestLen = length
-					bestOff = off
-					if bestLen == MaxMatch {
-						break
-					}
+			if length > bestLen {
+				bestLen = length
+				bestOff = off
+				if bestLen == maxLen {
+					break
 				}
 			}
 		}
 
-		if bestLen >= minMatch {
+		if e.bitCount == 0 {
+			e.flagPos = len(e.out)
+			e.out = append(e.out, 0)
+		}
+
+		if bestLen >= e.minMatch {
 			// Encode back-reference: LE 16-bit = [offset_lo8, (offset_hi4<<4)|(length-minMatch)]; length minMatch..minMatch+15.
-			offset := bestOff
-			length := bestLen
-			maxEncLen := minMatch + 15
-			if length > maxEncLen {
-				length = maxEncLen
-			}
-			low := offset & 0xFF
-			hi4 := (offset & 0x0F00) << 4
-			pLen := (length - minMatch) << 8
+			low := bestOff & 0xFF
+			hi4 := (bestOff & 0x0F00) << 4
+			pLen := (bestLen - e.minMatch) << 8
 			pointer := uint16(hi4 | low | pLen) // #nosec G115
-			out = append(out, byte(pointer&0xFF), byte(pointer>>8))
-			outData = append(outData, src[i:i+length]...)
-			i += length
+			e.out = append(e.out, byte(pointer&0xFF), byte(pointer>>8))
+			i += bestLen
 		} else {
-			flagByte |= 1 << bitCount
-			out = append(out, src[i])
-			outData = append(outData, src[i])
+			e.flagByte |= 1 << e.bitCount
+			e.out = append(e.out, src[i])
 			i++
 		}
 
-		bitCount++
-		if bitCount == FlagBits {
-			writeFlags()
-			if i < len(src) {
-				startChunk()
-			}
+		e.bitCount++
+		if e.bitCount == FlagBits {
+			e.out[e.flagPos] = e.flagByte
+			e.flagByte = 0
+			e.bitCount = 0
 		}
 	}
 
-	if bitCount > 0 {
-		writeFlags()
+	return i
+}
+
+// finish closes the open flag byte and appends the checksum.
+func (e *encoder) finish(crc int32) {
+	if e.bitCount > 0 {
+		e.out[e.flagPos] = e.flagByte
+		e.flagByte = 0
+		e.bitCount = 0
+	}
+
+	e.out = binary.LittleEndian.AppendUint32(e.out, uint32(crc)) // #nosec G115 -- store checksum bit pattern
+}
+
+// ready returns the length of the out prefix that is final (before the open flag byte).
+func (e *encoder) ready() int {
+	if e.bitCount > 0 {
+		return e.flagPos
+	}
+
+	return len(e.out)
+}
+
+// drop removes the first n bytes of out after they were written.
+func (e *encoder) drop(n int) {
+	e.out = e.out[:copy(e.out, e.out[n:])]
+	e.flagPos -= n
+}
+
+// matchLen returns the length of the common prefix of a and b (len(a) == len(b)),
+// comparing 8 bytes per step. Used by every match finder.
+func matchLen(a, b []byte) int {
+	b = b[:len(a)]
+	n := 0
+	for len(a) >= 8 {
+		if x := binary.LittleEndian.Uint64(a) ^ binary.LittleEndian.Uint64(b); x != 0 {
+			return n + bits.TrailingZeros64(x)>>3
+		}
+
+		a, b = a[8:], b[8:]
+		n += 8
 	}
 
-	buf := make([]byte, 4)
-	binary.LittleEndian.PutUint32(buf, uint32(crc)) // #nosec G115 -- store checksum bit pattern
-	out = append(out, buf...)
+	for i := range a {
+		if a[i] != b[i] {
+			return n + i
+		}
+	}
 
-	return out, nil
+	return n + len(a)
 }
@@ -6,7 +6,7 @@ package lzss
 
 import (
 	"bufio"
-	"encoding/binary"
+	"bytes"
 	"fmt"
 	"io"
 )
@@ -24,7 +24,12 @@ func Decompress(src []byte, outLen int, opts *Options) ([]byte, error) {
 	}
 
 	if consumed != len(src) {
-		return nil, fmt.Errorf("%w: consumed=%d input=%d", ErrTrailingData, consumed, len(src))
+		return nil, &DecodeError{
+			Err:       fmt.Errorf("%w: consumed=%d input=%d", ErrTrailingData, consumed, len(src)),
+			InOffset:  int64(consumed),
+			OutOffset: outLen,
+			Bit:       -1,
+		}
 	}
 
 	return out, nil
@@ -38,29 +43,66 @@ func DecompressBlock(src []byte, outLen int, opts *Options) ([]byte, int, error)
 		return nil, 0, ErrInputTooShort
 	}
 
-	reader := &sliceByteReader{data: src}
-	out, err := decompressFromByteReader(reader, outLen, opts)
+	out, info, err := DecompressBlockInfo(src, outLen, opts)
+
+	return out, int(info.Consumed), err
+}
+
+// DecompressPrefix decodes only the first n bytes (at most outLen) of the block at the start of src,
+// e.g. to sniff the content type of an archive entry. Decoding stops at the first token boundary
+// that covers n bytes and the checksum is not read. It returns the decoded prefix and the input
+// offset reached; on a decode error it returns the bytes decoded before it.
+func DecompressPrefix(src []byte, outLen, n int, opts *Options) ([]byte, int, error) {
+	zr, err := NewReader(bytes.NewReader(src), outLen, opts)
 	if err != nil {
-		return nil, reader.pos, err
+		return nil, 0, err
+	}
+
+	out := make([]byte, min(max(n, 0), outLen))
+	got, err := io.ReadFull(zr, out)
+
+	return out[:got], int(zr.Consumed()), err
+}
+
+// DecompressBlockInfo is like DecompressBlock but returns BlockInfo with consumed bytes
+// and stored/computed checksums. In lenient mode a mismatch is reported via
+// BlockInfo.ChecksumMatch; in strict mode the error is a *ChecksumError.
+func DecompressBlockInfo(src []byte, outLen int, opts *Options) ([]byte, BlockInfo, error) {
+	if len(src) < 4 {
+		return nil, BlockInfo{}, ErrInputTooShort
 	}
 
-	return out, reader.pos, nil
+	return decompressSlice(src, outLen, opts)
 }
 
 // DecompressFromReader decompresses one LZSS block from r and returns consumed bytes.
-// Decoding stops exactly after outLen output bytes and trailing 4-byte checksum are read.
+// Decoding stops exactly after outLen output bytes and trailing 4-byte checksum are read,
+// and r is left positioned right after the block: an io.ByteReader (bytes.Reader, bufio.Reader)
+// is read byte by byte, a seekable reader (*os.File) is buffered and seeked back,
+// other readers (pipes, net.Conn) are read one byte per Read call; wrap them in bufio.Reader
+// and keep reading from it for speed.
 func DecompressFromReader(r io.Reader, outLen int, opts *Options) ([]byte, int64, error) {
+	out, info, err := DecompressFromReaderInfo(r, outLen, opts)
+
+	return out, info.Consumed, err
+}
+
+// DecompressFromReaderInfo is like DecompressFromReader but returns BlockInfo
+// with consumed bytes and stored/computed checksums.
+func DecompressFromReaderInfo(r io.Reader, outLen int, opts *Options) ([]byte, BlockInfo, error) {
 	countingReader, err := newCountingByteReader(r)
 	if err != nil {
-		return nil, 0, err
+		return nil, BlockInfo{}, err
 	}
 
-	out, err := decompressFromByteReader(countingReader, outLen, opts)
+	out, info, err := decompressFromByteReader(countingReader, outLen, opts)
+	info.Consumed = countingReader.count
+	err = releaseReader(countingReader, err)
 	if err != nil {
-		return nil, countingReader.count, err
+		return nil, info, err
 	}
 
-	return out, countingReader.count, nil
+	return out, info, nil
 }
 
 // DecompressNFromReader decompresses N LZSS blocks from r with expected output lengths.
@@ -73,15 +115,16 @@ func DecompressNFromReader(r io.Reader, outLens []int, opts *Options) ([][]byte,
 
 	blocks := make([][]byte, 0, len(outLens))
 	for i, outLen := range outLens {
-		block, decodeErr := decompressFromByteReader(countingReader, outLen, opts)
+		base := countingReader.count
+		block, _, decodeErr := decompressFromByteReader(countingReader, outLen, opts)
 		if decodeErr != nil {
-			return blocks, countingReader.count, fmt.Errorf("decode block %d: %w", i, decodeErr)
+			return blocks, countingReader.count, releaseReader(countingReader, withBlock(decodeErr, i, base))
 		}
 
 		blocks = append(blocks, block)
 	}
 
-	return blocks, countingReader.count, nil
+	return blocks, countingReader.count, releaseReader(countingReader, nil)
 }
 
 // DecompressUntilEOF decompresses blocks from r while nextOutLen returns (outLen, true).
@@ -103,51 +146,66 @@ func DecompressUntilEOF(r io.Reader, nextOutLen func() (int, bool), opts *Option
 			break
 		}
 
-		block, decodeErr := decompressFromByteReader(countingReader, outLen, opts)
+		base := countingReader.count
+		block, _, decodeErr := decompressFromByteReader(countingReader, outLen, opts)
 		if decodeErr != nil {
-			return blocks, countingReader.count, fmt.Errorf("decode block %d: %w", i, decodeErr)
+			return blocks, countingReader.count, releaseReader(countingReader, withBlock(decodeErr, i, base))
 		}
 
 		blocks = append(blocks, block)
 	}
 
-	return blocks, countingReader.count, nil
+	return blocks, countingReader.count, releaseReader(countingReader, nil)
 }
 
+// newCountingByteReader returns a byte reader over r that reads no further than decoding needs:
+// an io.ByteReader is used as is, an io.Seeker is read through a buffer that release returns
+// by seeking back, and other readers are read one byte per call.
 func newCountingByteReader(r io.Reader) (*countingByteReader, error) {
 	if r == nil {
 		return nil, ErrNilReader
 	}
 
-	var byteReader io.ByteReader
-	if existing, ok := r.(io.ByteReader); ok {
-		byteReader = existing
-	} else {
-		byteReader = bufio.NewReader(r)
+	if byteReader, ok := r.(io.ByteReader); ok {
+		return &countingByteReader{base: byteReader}, nil
+	}
+
+	// Pipes and terminals opened as *os.File implement io.Seeker but fail to seek.
+	if seeker, ok := r.(io.ReadSeeker); ok {
+		if _, err := seeker.Seek(0, io.SeekCurrent); err == nil {
+			buf := bufio.NewReader(r)
+
+			return &countingByteReader{base: buf, seeker: seeker, buf: buf}, nil
+		}
+	}
+
+	return &countingByteReader{base: &unbufferedByteReader{r: r}}, nil
+}
+
+// releaseReader returns read-ahead of r to its source and keeps the first error.
+func releaseReader(r *countingByteReader, err error) error {
+	if releaseErr := r.release(); err == nil && releaseErr != nil {
+		return &DecodeError{Err: releaseErr, InOffset: r.count, Bit: -1}
 	}
 
-	return &countingByteReader{base: byteReader}, nil
+	return err
 }
 
 // decompressFromByteReader decompresses from a byte reader.
-func decompressFromByteReader(r io.ByteReader, outLen int, opts *Options) ([]byte, error) {
+// Returned BlockInfo has checksum fields filled once the checksum is read; Consumed is set by callers.
+// Errors are *DecodeError with input offset relative to the first byte read by this call.
+func decompressFromByteReader(r io.ByteReader, outLen int, opts *Options) ([]byte, BlockInfo, error) {
 	if opts == nil {
 		opts = DefaultOptions()
 	}
 
 	if outLen < 0 {
-		return nil, ErrNegativeOutLen
-	}
-
-	minMatch := opts.MinMatchLength
-	if minMatch == 0 {
-		minMatch = MinMatchDefault
+		return nil, BlockInfo{}, ErrNegativeOutLen
 	}
 
 	signed := opts.Checksum == ChecksumSigned
 	var calcCrc int32
 	out := make([]byte, outLen)
-	pos := 0
 
 	addChecksum := func(b byte) {
 		if signed {
@@ -157,134 +215,74 @@ func decompressFromByteReader(r io.ByteReader, outLen int, opts *Options) ([]byt
 		}
 	}
 
-	// Read a byte from the reader.
-	// If the reader returns an EOF error, return the error passed as eofErr.
-	// Otherwise, return the error from the reader.
-	readByte := func(eofErr error) (byte, error) {
-		b, err := r.ReadByte()
+	w := newTokenWalker(r, opts)
+	for {
+		t, ok, err := w.next(outLen)
 		if err != nil {
-			if err == io.EOF {
-				return 0, eofErr
-			}
-
-			return 0, err
+			return nil, BlockInfo{}, err
+		}
+		if !ok {
+			break
 		}
 
-		return b, nil
-	}
-
-	// Iterate over output bytes.
-	for pos < outLen {
-		flagByte, err := readByte(ErrUnexpectedEOF)
-		if err != nil {
-			return nil, err
+		pos := w.pos
+		if t.literal {
+			out[pos] = t.b
+			addChecksum(t.b)
+			continue
 		}
 
-		// Iterate over flag bytes for each output byte.
-		for bit := range FlagBits {
-			if pos >= outLen {
-				break
+		rpos := pos - t.offset // source start in output buffer
+		need := t.length       // bytes to copy (may be capped by outLen later)
+
+		// Offset can refer before start of output: fill with Filler (0x20) for those bytes.
+		if rpos < 0 {
+			fillCount := min(-rpos, need)
+			endFill := min(pos+fillCount, outLen)
+			for j := pos; j < endFill; j++ {
+				out[j] = Filler
+				addChecksum(Filler)
 			}
+			pos += fillCount
+			need -= fillCount
+			rpos = 0
+		}
 
-			// If bit is 1, it's a literal: 1 bit, 1 byte otherwise it's a pointer.
-			if (flagByte>>bit)&1 == 1 {
-				b, err := readByte(ErrUnexpectedEOFBit)
-				if err != nil {
-					return nil, err
+		// Copy bytes from source to output.
+		if need > 0 && pos < outLen {
+			need = min(need, outLen-pos)
+			// Overlapping back-ref (offset < need): must copy byte-by-byte so each written byte
+			// is visible to the next read (RLE-like). copy(dst, src) does not handle overlap.
+			if t.offset < need {
+				for k := range need {
+					b := out[rpos+k]
+					out[pos+k] = b
+					addChecksum(b)
 				}
-
-				out[pos] = b
-				addChecksum(b)
-				pos++
 			} else {
-				lo, err := readByte(ErrUnexpectedEOFBit)
-				if err != nil {
-					return nil, err
-				}
-				hi, err := readByte(ErrUnexpectedEOFBit)
-				if err != nil {
-					return nil, err
-				}
-
-				// Pointer: LE 16-bit = [offset_lo8, (offset_hi4<<4)|(length-minMatch)]; offset is backward from pos.
-				pointer := uint16(lo) | (uint16(hi) << 8)
-				low8 := int(pointer & 0xFF)
-				hi4 := int((pointer & 0xF000) >> 12)
-				offset := low8 + (hi4 << 8)
-				length := int((pointer&0x0F00)>>8) + minMatch
-
-				rpos := pos - offset // source start in output buffer
-				need := length       // bytes to copy (may be capped by outLen later)
-
-				// Offset can refer before start of output: fill with Filler (0x20) for those bytes.
-				if rpos < 0 {
-					fillCount := min(-rpos, need)
-					endFill := min(pos+fillCount, outLen)
-					for j := pos; j < endFill; j++ {
-						out[j] = Filler
-						addChecksum(Filler)
-					}
-					pos += fillCount
-					need -= fillCount
-					rpos = 0
-				}
-
-				// Copy bytes from source to output.
-				if need > 0 && pos < outLen {
-					if pos+need > outLen {
-						need = outLen - pos
-					}
-					// Overlapping back-ref (offset < need): must copy byte-by-byte so each written byte
-					// is visible to the next read (RLE-like). copy(dst, src) does not handle overlap.
-					if offset < need {
-						for k := 0; k < need; k++ {
-							b := out[rpos+k]
-							out[pos+k] = b
-							addChecksum(b)
-						}
-					} else {
-						copy(out[pos:pos+need], out[rpos:rpos+need])
-						for k := 0; k < need; k++ {
-							addChecksum(out[pos+k])
-						}
-					}
-					pos += need
+				copy(out[pos:pos+need], out[rpos:rpos+need])
+				for k := range need {
+					addChecksum(out[pos+k])
 				}
 			}
-
-			if pos >= outLen {
-				break
-			}
 		}
+	}
 
-		if pos >= outLen {
-			break
-		}
+	stored, err := w.checksum(outLen)
+	if err != nil {
+		return nil, BlockInfo{}, err
 	}
 
-	var checksumBytes [4]byte
-	for i := range 4 {
-		b, err := readByte(ErrInputTooShort)
-		if err != nil {
-			return nil, err
-		}
-		checksumBytes[i] = b
+	info := BlockInfo{
+		StoredChecksum:   stored,
+		ComputedChecksum: uint32(calcCrc), // #nosec G115 -- checksum bit pattern
+		Mode:             opts.Checksum,
 	}
-	readCrc := binary.LittleEndian.Uint32(checksumBytes[:])
+	info.ChecksumMatch = info.StoredChecksum == info.ComputedChecksum
 
-	if opts.VerifyChecksum {
-		if signed {
-			// #nosec G115 -- intentional: compare stored uint32 as int32 for signed checksum
-			if calcCrc != int32(readCrc) {
-				return nil, fmt.Errorf("checksum mismatch (signed): got=0x%x expected=0x%x", uint32(calcCrc), readCrc)
-			}
-		} else {
-			// #nosec G115 -- intentional: compare int32 sum as uint32 for unsigned checksum
-			if uint32(calcCrc) != readCrc {
-				return nil, fmt.Errorf("checksum mismatch (unsigned): got=0x%x expected=0x%x", uint32(calcCrc), readCrc)
-			}
-		}
+	if opts.VerifyChecksum && !info.ChecksumMatch {
+		return nil, info, w.fail(info.ChecksumError())
 	}
 
-	return out, nil
+	return out, info, nil
 }
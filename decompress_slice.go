@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 Maxim Levchenko (WoozyMasta)
+// Source: github.com/woozymasta/lzss
+
+package lzss
+
+import "encoding/binary"
+
+// decompressSlice decodes one block from the start of src.
+// Output, BlockInfo and *DecodeError positions are identical to decompressFromByteReader
+// over the same bytes; it only avoids per-byte interface calls:
+// full literal groups are copied 8 bytes at once, matches use copy
+// (pattern doubling for overlaps) and the checksum is summed over the output at the end.
+// BlockInfo.Consumed is set, on error to the input read so far.
+func decompressSlice(src []byte, outLen int, opts *Options) ([]byte, BlockInfo, error) {
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+
+	if outLen < 0 {
+		return nil, BlockInfo{}, ErrNegativeOutLen
+	}
+
+	minMatch := opts.MinMatchLength
+	if minMatch == 0 {
+		minMatch = MinMatchDefault
+	}
+
+	out := make([]byte, outLen)
+	in, pos := 0, 0
+
+	// Decoder position, reported in DecodeError.
+	var flagByte byte
+	bit := -1
+
+	// fail returns err with the decoder position; Consumed reports input read so far.
+	fail := func(err error) ([]byte, BlockInfo, error) {
+		return nil, BlockInfo{Consumed: int64(in)}, &DecodeError{Err: err, InOffset: int64(in), OutOffset: pos, Flag: flagByte, Bit: bit}
+	}
+
+	for pos < outLen {
+		bit = -1
+		if in >= len(src) {
+			return fail(ErrUnexpectedEOF)
+		}
+		flagByte = src[in]
+		in++
+
+		// Eight literals: no pointer and no final-group checks apply.
+		if flagByte == 0xFF && pos+FlagBits <= outLen && in+FlagBits <= len(src) {
+			copy(out[pos:pos+FlagBits], src[in:in+FlagBits])
+			pos += FlagBits
+			in += FlagBits
+			bit = FlagBits - 1
+
+			continue
+		}
+
+		for bit = 0; bit < FlagBits; bit++ {
+			if pos >= outLen {
+				break
+			}
+
+			if (flagByte>>bit)&1 == 1 {
+				if in >= len(src) {
+					return fail(ErrUnexpectedEOFBit)
+				}
+
+				out[pos] = src[in]
+				in++
+				pos++
+			} else {
+				if in+2 > len(src) {
+					in = len(src)
+
+					return fail(ErrUnexpectedEOFBit)
+				}
+
+				pointer := binary.LittleEndian.Uint16(src[in:])
+				in += 2
+				offset := int(pointer&0xFF) | int((pointer&0xF000)>>4)
+				length := int((pointer&0x0F00)>>8) + minMatch
+
+				rpos := pos - offset
+				need := length
+
+				if opts.Strict {
+					switch {
+					case offset == 0:
+						return fail(ErrZeroOffset)
+					case rpos < 0:
+						return fail(ErrFillerRef)
+					case pos+length > outLen:
+						return fail(ErrMatchOverrun)
+					}
+				}
+
+				// Bytes before output start are Filler; pos may pass outLen here as in the reader path.
+				if rpos < 0 {
+					fillCount := min(-rpos, need)
+					fill := out[pos:min(pos+fillCount, outLen)]
+					for j := range fill {
+						fill[j] = Filler
+					}
+					pos += fillCount
+					need -= fillCount
+					rpos = 0
+				}
+
+				if need > 0 && pos < outLen {
+					need = min(need, outLen-pos)
+					end := pos + need
+
+					switch {
+					case offset == 0:
+						// Refers to output not yet written, which is still zero.
+					case offset < need:
+						// Overlap: out[rpos:w] is a whole number of periods, so each copy doubles it.
+						for w := pos; w < end; {
+							w += copy(out[w:end], out[rpos:w])
+						}
+					default:
+						copy(out[pos:end], out[rpos:rpos+need])
+					}
+					pos = end
+				}
+			}
+
+			if pos >= outLen {
+				break
+			}
+		}
+
+		if pos >= outLen {
+			// Unused slots of the final flag group must be zero in strict mode.
+			if opts.Strict && bit < FlagBits-1 && flagByte>>(bit+1) != 0 {
+				return fail(ErrFlagBitsBeyondEnd)
+			}
+
+			break
+		}
+	}
+
+	pos = min(pos, outLen)
+	bit = -1
+
+	if in+4 > len(src) {
+		in = len(src)
+
+		return fail(ErrInputTooShort)
+	}
+
+	var computed int32
+	if opts.Checksum == ChecksumSigned {
+		computed = sumSigned(out)
+	} else {
+		computed = sumUnsigned(out)
+	}
+
+	info := BlockInfo{
+		Consumed:         int64(in + 4),
+		StoredChecksum:   binary.LittleEndian.Uint32(src[in:]),
+		ComputedChecksum: uint32(computed), // #nosec G115 -- checksum bit pattern
+		Mode:             opts.Checksum,
+	}
+	info.ChecksumMatch = info.StoredChecksum == info.ComputedChecksum
+	in += 4
+
+	if opts.VerifyChecksum && !info.ChecksumMatch {
+		_, _, err := fail(info.ChecksumError())
+
+		return nil, info, err
+	}
+
+	return out, info, nil
+}
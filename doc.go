@@ -16,6 +16,26 @@ Use DecompressBlock(src, outLen, opts) to decode from the beginning of src and g
 Use DecompressFromReader(r, outLen, opts) to decode one block from a stream without reading to EOF.
 Use DecompressNFromReader(r, outLens, opts) to decode multiple blocks with known output sizes.
 Use DecompressUntilEOF(r, nextOutLen, opts) when output size is provided by a callback.
+Use Reader.Checkpoint and NewReaderFromCheckpoint to save and resume incremental decoding.
+Use DecompressPrefix(src, outLen, n, opts) to decode only the first n bytes for content sniffing.
+Use BlockSize(src, outLen, opts) or BlockSizeFromReader to find the compressed size of a block
+without decoding it, and ValidateBlock to also verify its checksum without allocating outLen bytes.
+Use MaxDecodedSize(packedLen, opts) to reject container size fields a block cannot expand to.
+Use CompressNToWriter(w, blocks, opts) or NewBlockWriter to write back-to-back blocks with per-block sizes.
+Use CompressToWriter(w, r, opts) to compress one block from a reader holding only the match window in memory.
+Use DecompressSeq and DecompressNSeq to iterate blocks one at a time (iter.Seq2[Block, error]).
+Use NewReader(r, outLen, opts) to decode one block incrementally as io.Reader.
+Use NewFS(base, resolve) to expose compressed files of an fs.FS as decoded files.
+Use DecompressBlockInfo or DecompressFromReaderInfo to get stored and computed checksums in BlockInfo.
+Set Options.Strict to reject filler references, zero offsets and overrunning matches.
+Use NewFrameWriter and NewFrameReader for self-describing framed files (options in header, block sizes inline).
+Use NewSeekableWriter and NewSeekableReader for random access (io.ReaderAt) over indexed blocks.
+Use Estimate(src, opts) to predict compressed size and CompressIfSmaller to store incompressible data as is.
+Use Analyze(src, opts) to get token statistics (match lengths, offsets, window use) for tuning.
+Use Preset(name) for options of a known dialect (pbo, paa, mm2), RegisterPreset to add one
+and PresetNames(opts) to report options by preset name.
+Use Recompress(src, outLen, in, out) or RecompressNToWriter to convert blocks between dialects.
+Use Scan(data, opts) to find embedded blocks at unknown offsets in other file formats.
 Use SignedLenientOptions() for formats that use signed checksum and ignore mismatch.
 Set Options.MinMatchLength or CompressOptions.MinMatchLength to MinMatch2 for 2..17 back-ref length.
 
@@ -62,6 +82,18 @@ Decompress with signed checksum and skip verification (lenient):
 	opts := lzss.SignedLenientOptions()
 	out, err := lzss.Decompress(src, outLen, opts)
 
+Report a bad checksum without failing (lenient) or inspect it in strict mode:
+
+	out, info, err := lzss.DecompressBlockInfo(src, outLen, lzss.SignedLenientOptions())
+	if err == nil && !info.ChecksumMatch {
+		log.Printf("bad checksum: %v", info.ChecksumError())
+	}
+
+	var csErr *lzss.ChecksumError
+	if errors.As(err, &csErr) {
+		log.Printf("stored=0x%x computed=0x%x", csErr.Stored, csErr.Computed)
+	}
+
 Compress and decompress with min match length 2 (back-ref length 2..17):
 
 	copts := &lzss.CompressOptions{SearchLimit: 2048, MinMatchLength: lzss.MinMatch2}
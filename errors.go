@@ -4,7 +4,10 @@
 
 package lzss
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // Package errors. Use errors.New for static messages, fmt.Errorf when values are needed.
 var (
@@ -16,4 +19,84 @@ var (
 	ErrNilOutLenProvider = errors.New("outLen provider is nil")
 	ErrNegativeOutLen    = errors.New("output length must be non-negative")
 	ErrEmptyInput        = errors.New("input is empty")
+	ErrChecksumMismatch  = errors.New("checksum mismatch")
+	ErrFillerRef         = errors.New("back-reference before start of output")
+	ErrZeroOffset        = errors.New("back-reference with zero offset")
+	ErrMatchOverrun      = errors.New("back-reference length overruns output length")
+	ErrFlagBitsBeyondEnd = errors.New("flag bits set beyond end of output")
+	ErrWriterClosed      = errors.New("writer is closed")
+	ErrReaderClosed      = errors.New("reader is closed")
+	ErrFrameMagic        = errors.New("not an lzss frame")
+	ErrFrameVersion      = errors.New("unsupported lzss frame version")
+	ErrFrameCorrupt      = errors.New("corrupt lzss frame")
+	ErrFrameTooLarge     = errors.New("lzss frame block exceeds 4 GiB")
+	ErrSeekableMagic     = errors.New("not a seekable lzss file")
+	ErrSeekableCorrupt   = errors.New("corrupt seekable lzss index")
+	ErrNegativeOffset    = errors.New("negative offset")
+	ErrInvalidWhence     = errors.New("invalid whence")
+	ErrInvalidManifest   = errors.New("invalid lzss manifest")
+	ErrInvalidCheckpoint = errors.New("invalid lzss checkpoint")
+	ErrUnknownPreset     = errors.New("unknown lzss preset")
+	ErrInvalidPreset     = errors.New("invalid lzss preset")
+	ErrPresetExists      = errors.New("lzss preset already registered")
 )
+
+// ChecksumError reports a checksum mismatch in strict mode.
+// It matches ErrChecksumMismatch with errors.Is and can be extracted with errors.As.
+type ChecksumError struct {
+	Mode     ChecksumMode // Checksum mode used for the computed value.
+	Stored   uint32       // Checksum stored in the trailing 4 bytes of the block.
+	Computed uint32       // Checksum computed over decoded output.
+}
+
+// Error implements error.
+func (e *ChecksumError) Error() string {
+	mode := "unsigned"
+	if e.Mode == ChecksumSigned {
+		mode = "signed"
+	}
+
+	return fmt.Sprintf("checksum mismatch (%s): got=0x%x expected=0x%x", mode, e.Computed, e.Stored)
+}
+
+// Unwrap returns ErrChecksumMismatch.
+func (e *ChecksumError) Unwrap() error {
+	return ErrChecksumMismatch
+}
+
+// DecodeError reports where in the stream decoding failed.
+// It wraps the underlying error, so errors.Is(err, ErrUnexpectedEOFBit) and
+// errors.As(err, &checksumErr) keep working. Decode functions return every decoding and reader
+// error as *DecodeError. Invalid arguments (nil reader or outLen provider, negative outLen,
+// input shorter than a checksum) and Reader usage errors (ErrReaderClosed, ErrInvalidCheckpoint)
+// are returned as bare sentinels, since no decoding position exists for them.
+type DecodeError struct {
+	Err       error // Underlying error (sentinel, *ChecksumError or reader error).
+	InOffset  int64 // Input offset of the failing read, from the start of decoding.
+	Block     int   // Block index (0 for single-block functions).
+	OutOffset int   // Output position within the block when decoding failed.
+	Bit       int   // Slot index in the flag byte (0..7); -1 when reading a flag byte or checksum.
+	Flag      byte  // Current flag byte (0 before the first flag byte is read).
+}
+
+// Error implements error.
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("decode block %d at input=%d output=%d flag=0x%02x bit=%d: %v",
+		e.Block, e.InOffset, e.OutOffset, e.Flag, e.Bit, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// withBlock sets block index and shifts input offset by base when err is a *DecodeError.
+func withBlock(err error, block int, base int64) error {
+	var decErr *DecodeError
+	if errors.As(err, &decErr) {
+		decErr.Block = block
+		decErr.InOffset += base
+	}
+
+	return err
+}
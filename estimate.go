@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 Maxim Levchenko (WoozyMasta)
+// Source: github.com/woozymasta/lzss
+
+package lzss
+
+const (
+	// estimateSampleSize is the size of one probed sample (one window).
+	estimateSampleSize = WindowSize
+	// estimateSamples is the number of samples probed for inputs larger than estimateSamples*estimateSampleSize.
+	estimateSamples = 16
+	// estimateChain is the number of match candidates checked per position.
+	estimateChain = 8
+	// estimateHashBits is the size of the probe hash table (1<<estimateHashBits heads).
+	estimateHashBits = 12
+)
+
+// Estimate predicts the size of Compress(src, opts) without compressing.
+// Inputs up to 64 KiB are probed fully, larger inputs through 16 evenly spaced 4 KiB samples;
+// the probe checks a few hashed match candidates per position, so it finds fewer matches
+// than Compress and usually overestimates. Literals-only options (SearchLimit 0) give the exact size.
+// Options nil means DefaultCompressOptions(). Empty src returns 0.
+func Estimate(src []byte, opts *CompressOptions) int {
+	if opts == nil {
+		opts = DefaultCompressOptions()
+	}
+	if len(src) == 0 {
+		return 0
+	}
+
+	limit := min(opts.SearchLimit, WindowSize-1)
+	if limit <= 0 {
+		return len(src) + (len(src)+FlagBits-1)/FlagBits + 4
+	}
+
+	minMatch := opts.MinMatchLength
+	if minMatch == 0 {
+		minMatch = MinMatchDefault
+	}
+
+	p := newMatchProbe(min(len(src), estimateSamples*estimateSampleSize))
+	var literals, pointers, sampled int64
+	if len(src) <= estimateSamples*estimateSampleSize {
+		literals, pointers = p.run(src, limit, minMatch)
+		sampled = int64(len(src))
+	} else {
+		step := (len(src) - estimateSampleSize) / (estimateSamples - 1)
+		for k := range estimateSamples {
+			lo := k * step
+			l, ptr := p.run(src[lo:lo+estimateSampleSize], limit, minMatch)
+			literals += l
+			pointers += ptr
+		}
+		sampled = estimateSamples * estimateSampleSize
+	}
+
+	// Cost in eighths of a byte: literal 1 byte, pointer 2 bytes, plus one flag bit per slot.
+	cost8 := 9*literals + 17*pointers
+	n := int64(len(src))
+
+	return int((cost8*n+8*sampled-1)/(8*sampled)) + 4
+}
+
+// CompressIfSmaller compresses src when it saves at least minSavings bytes (at least 1).
+// It returns the compressed block and true, or src itself and false when compression does not pay off.
+// Input that Estimate predicts as incompressible is not compressed at all,
+// which avoids spending CPU on already compressed payloads.
+// Options nil means DefaultCompressOptions().
+func CompressIfSmaller(src []byte, opts *CompressOptions, minSavings int) ([]byte, bool, error) {
+	if len(src) == 0 {
+		return nil, false, ErrEmptyInput
+	}
+
+	minSavings = max(minSavings, 1)
+
+	// Estimate overshoots on sparse matches; allow 1/16 of input as tolerance before skipping.
+	if Estimate(src, opts)-len(src)/16 > len(src)-minSavings {
+		return src, false, nil
+	}
+
+	enc, err := Compress(src, opts)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(src)-len(enc) < minSavings {
+		return src, false, nil
+	}
+
+	return enc, true, nil
+}
+
+// matchProbe is a hash-chain match finder used by Estimate.
+type matchProbe struct {
+	head []int32
+	prev []int32
+}
+
+// newMatchProbe allocates a probe for samples up to size bytes.
+func newMatchProbe(size int) *matchProbe {
+	return &matchProbe{
+		head: make([]int32, 1<<estimateHashBits),
+		prev: make([]int32, size),
+	}
+}
+
+// run greedily parses sample and returns literal and pointer slot counts.
+func (p *matchProbe) run(sample []byte, limit, minMatch int) (literals, pointers int64) {
+	for i := range p.head {
+		p.head[i] = -1
+	}
+
+	maxLen := minMatch + 15
+	i := 0
+	for i < len(sample) {
+		bestLen := 0
+		if i+minMatch <= len(sample) {
+			h := probeHash(sample[i:], minMatch)
+			// Matches may overlap the bytes being produced, as in Compress.
+			lookahead := min(maxLen, len(sample)-i)
+			for cand, n := p.head[h], 0; cand >= 0 && n < estimateChain; cand, n = p.prev[cand], n+1 {
+				off := i - int(cand)
+				if off > limit {
+					break
+				}
+
+				bestLen = max(bestLen, matchLen(sample[int(cand):int(cand)+lookahead], sample[i:i+lookahead]))
+			}
+		}
+
+		if bestLen < minMatch {
+			bestLen = 1
+			literals++
+		} else {
+			pointers++
+		}
+
+		for end := i + bestLen; i < end; i++ {
+			if i+minMatch <= len(sample) {
+				h := probeHash(sample[i:], minMatch)
+				p.prev[i] = p.head[h]
+				p.head[h] = int32(i) // #nosec G115 -- sample size is bounded
+			}
+		}
+	}
+
+	return literals, pointers
+}
+
+// probeHash hashes the first minMatch (2 or 3) bytes of b.
+func probeHash(b []byte, minMatch int) uint32 {
+	v := uint32(b[0]) | uint32(b[1])<<8
+	if minMatch > 2 {
+		v |= uint32(b[2]) << 16
+	}
+
+	return (v * 2654435761) >> (32 - estimateHashBits)
+}
@@ -0,0 +1,430 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 Maxim Levchenko (WoozyMasta)
+// Source: github.com/woozymasta/lzss
+
+package lzss
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Frame format constants.
+//
+// Layout (little-endian):
+//
+//	magic    [4]byte "LZSF"
+//	version  uint8   FrameVersion
+//	flags    uint8   FrameFlagSigned | FrameFlagMinMatch2
+//	reserved uint16  zero
+//	blocks   count x {raw uint32, packed uint32, LZSS:8bit block (data + checksum)}
+//	end      raw uint32 zero, packed uint32 zero, size uint64 original (decoded) size
+//
+// Block sizes precede each block and the original size is in the end record, so the writer
+// streams blocks as they are compressed and the reader decodes from non-seekable input.
+const (
+	// FrameMagic identifies a framed .lzss file.
+	FrameMagic = "LZSF"
+
+	// FrameVersion is the current frame format version.
+	FrameVersion = 1
+
+	// FrameFlagSigned marks blocks with signed checksum.
+	FrameFlagSigned = 0x01
+
+	// FrameFlagMinMatch2 marks blocks encoded with MinMatch2.
+	FrameFlagMinMatch2 = 0x02
+
+	// DefaultFrameBlockSize is the default decoded size of one frame block.
+	DefaultFrameBlockSize = 1 << 20
+
+	// frameHeaderSize is the fixed header size before the first block record.
+	frameHeaderSize = 8
+
+	// frameRecordSize is the size of the sizes record before each block and of the end marker.
+	frameRecordSize = 8
+
+	// frameEndSize is the size of the end record including the original size.
+	frameEndSize = frameRecordSize + 8
+
+	// frameKnownFlags are flags understood by this version.
+	frameKnownFlags = FrameFlagSigned | FrameFlagMinMatch2
+)
+
+// FrameHeader is the parsed header of a framed file.
+// FrameReader fills Blocks as blocks are decoded and Size at the end record;
+// ReadFrameHeader returns them complete without decoding.
+type FrameHeader struct {
+	Blocks  []FrameBlock // Block sizes in stream order.
+	Size    uint64       // Original (decoded) size.
+	Version uint8        // Format version.
+	Flags   uint8        // Option flags (FrameFlagSigned, FrameFlagMinMatch2).
+}
+
+// FrameBlock holds the sizes recorded before one block.
+type FrameBlock struct {
+	RawSize    uint32 // Decoded size of the block.
+	PackedSize uint32 // Size of the LZSS block including checksum.
+}
+
+// Options returns decode options for the frame: checksum and min match from flags, strict verification.
+func (h *FrameHeader) Options() *Options {
+	return flagsOptions(h.Flags)
+}
+
+// flagsOptions returns strict decode options for frame flags.
+func flagsOptions(flags uint8) *Options {
+	opts := DefaultOptions()
+	if flags&FrameFlagSigned != 0 {
+		opts.Checksum = ChecksumSigned
+	}
+	if flags&FrameFlagMinMatch2 != 0 {
+		opts.MinMatchLength = MinMatch2
+	}
+
+	return opts
+}
+
+// frameFlags returns header flags for compression options.
+func frameFlags(opts *CompressOptions) uint8 {
+	var flags uint8
+	if opts.Checksum == ChecksumSigned {
+		flags |= FrameFlagSigned
+	}
+	if opts.MinMatchLength == MinMatch2 {
+		flags |= FrameFlagMinMatch2
+	}
+
+	return flags
+}
+
+// FrameWriter writes a framed file. Input is split into blocks of BlockSize decoded bytes,
+// each compressed with Compress and written with its sizes as soon as it is full;
+// Close writes the last block and the end record.
+type FrameWriter struct {
+	w    io.Writer
+	opts *CompressOptions
+
+	buf []byte
+
+	size uint64
+
+	// BlockSize is the decoded size of one block; zero means DefaultFrameBlockSize.
+	// Set before the first Write.
+	BlockSize int
+
+	started bool
+	closed  bool
+}
+
+// NewFrameWriter returns a FrameWriter writing to w. Options nil means DefaultCompressOptions().
+func NewFrameWriter(w io.Writer, opts *CompressOptions) *FrameWriter {
+	if opts == nil {
+		opts = DefaultCompressOptions()
+	}
+
+	return &FrameWriter{w: w, opts: opts}
+}
+
+// Write buffers p and writes every full block.
+func (fw *FrameWriter) Write(p []byte) (int, error) {
+	if fw.closed {
+		return 0, ErrWriterClosed
+	}
+
+	blockSize := fw.blockSize()
+	written := 0
+	for len(p) > 0 {
+		n := min(len(p), blockSize-len(fw.buf))
+		fw.buf = append(fw.buf, p[:n]...)
+		p = p[n:]
+		written += n
+
+		if len(fw.buf) == blockSize {
+			if err := fw.writeBlock(); err != nil {
+				return written, err
+			}
+		}
+	}
+
+	return written, nil
+}
+
+// Close writes the remaining block and the end record. It does not close the underlying writer.
+func (fw *FrameWriter) Close() error {
+	if fw.closed {
+		return ErrWriterClosed
+	}
+	fw.closed = true
+
+	if len(fw.buf) > 0 {
+		if err := fw.writeBlock(); err != nil {
+			return err
+		}
+	}
+	if err := fw.writeHeader(); err != nil {
+		return err
+	}
+
+	var end [frameEndSize]byte
+	binary.LittleEndian.PutUint64(end[frameRecordSize:], fw.size)
+	_, err := fw.w.Write(end[:])
+
+	return err
+}
+
+// blockSize returns the effective block size.
+func (fw *FrameWriter) blockSize() int {
+	if fw.BlockSize <= 0 || uint64(fw.BlockSize) > math.MaxUint32 {
+		return DefaultFrameBlockSize
+	}
+
+	return fw.BlockSize
+}
+
+// writeHeader writes the fixed header before the first block record.
+func (fw *FrameWriter) writeHeader() error {
+	if fw.started {
+		return nil
+	}
+	fw.started = true
+
+	var header [frameHeaderSize]byte
+	copy(header[:], FrameMagic)
+	header[4] = FrameVersion
+	header[5] = frameFlags(fw.opts)
+	_, err := fw.w.Write(header[:])
+
+	return err
+}
+
+// writeBlock compresses the buffered data and writes it as one block with its sizes.
+func (fw *FrameWriter) writeBlock() error {
+	enc, err := Compress(fw.buf, fw.opts)
+	if err != nil {
+		return err
+	}
+	if uint64(len(enc)) > math.MaxUint32 {
+		return ErrFrameTooLarge
+	}
+	if err := fw.writeHeader(); err != nil {
+		return err
+	}
+
+	var record [frameRecordSize]byte
+	binary.LittleEndian.PutUint32(record[0:4], uint32(len(fw.buf))) // #nosec G115 -- bounded by blockSize
+	binary.LittleEndian.PutUint32(record[4:8], uint32(len(enc)))    // #nosec G115 -- range checked above
+	if _, err := fw.w.Write(record[:]); err != nil {
+		return err
+	}
+	if _, err := fw.w.Write(enc); err != nil {
+		return err
+	}
+
+	fw.size += uint64(len(fw.buf))
+	fw.buf = fw.buf[:0]
+
+	return nil
+}
+
+// FrameReader reads a framed file and returns decoded data.
+type FrameReader struct {
+	r    io.Reader // Also io.ByteReader, so blocks are read without extra buffering.
+	opts *Options
+	pend *bytes.Reader
+	err  error
+
+	// Header is the parsed frame header. Blocks grows as blocks are decoded;
+	// Blocks and Size are complete once Read returns io.EOF.
+	Header FrameHeader
+
+	total uint64
+}
+
+// NewFrameReader reads the frame header from r.
+// Decode options are taken from header flags with strict checksum verification.
+// If r is not an io.ByteReader it is wrapped in bufio.Reader, which may read past the frame.
+func NewFrameReader(r io.Reader) (*FrameReader, error) {
+	if r == nil {
+		return nil, ErrNilReader
+	}
+
+	if _, ok := r.(io.ByteReader); !ok {
+		r = bufio.NewReader(r)
+	}
+
+	header, err := readFrameHeader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FrameReader{r: r, opts: header.Options(), Header: *header}, nil
+}
+
+// Read implements io.Reader, decoding blocks as needed.
+func (fr *FrameReader) Read(p []byte) (int, error) {
+	for {
+		if fr.pend != nil && fr.pend.Len() > 0 {
+			return fr.pend.Read(p)
+		}
+		if fr.err != nil {
+			return 0, fr.err
+		}
+
+		fr.err = fr.decodeNext()
+	}
+}
+
+// decodeNext decodes the next block into pend; returns io.EOF after the end record.
+func (fr *FrameReader) decodeNext() error {
+	index := len(fr.Header.Blocks)
+	block, end, err := readFrameRecord(fr.r, index, fr.opts)
+	if err != nil {
+		return err
+	}
+	if end {
+		size, err := readFrameEnd(fr.r, fr.total)
+		if err != nil {
+			return err
+		}
+		fr.Header.Size = size
+
+		return io.EOF
+	}
+
+	out, consumed, err := DecompressFromReader(fr.r, int(block.RawSize), fr.opts)
+	if err != nil {
+		return withBlock(err, index, 0)
+	}
+	if consumed != int64(block.PackedSize) {
+		return fmt.Errorf("%w: block %d consumed=%d packed=%d", ErrFrameCorrupt, index, consumed, block.PackedSize)
+	}
+
+	fr.Header.Blocks = append(fr.Header.Blocks, block)
+	fr.total += uint64(block.RawSize)
+	fr.pend = bytes.NewReader(out)
+
+	return nil
+}
+
+// ReadFrameHeader reads a framed file from r and returns its complete header
+// without decoding blocks, e.g. to list block sizes. Block data is skipped with Seek
+// when r is an io.Seeker and read otherwise; r is left after the end record.
+func ReadFrameHeader(r io.Reader) (*FrameHeader, error) {
+	if r == nil {
+		return nil, ErrNilReader
+	}
+
+	header, err := readFrameHeader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var total uint64
+	for {
+		block, end, err := readFrameRecord(r, len(header.Blocks), header.Options())
+		if err != nil {
+			return nil, err
+		}
+		if end {
+			header.Size, err = readFrameEnd(r, total)
+			if err != nil {
+				return nil, err
+			}
+
+			return header, nil
+		}
+
+		if err := skipFrameBlock(r, int64(block.PackedSize)); err != nil {
+			return nil, fmt.Errorf("%w: block %d: %w", ErrFrameCorrupt, len(header.Blocks), err)
+		}
+		header.Blocks = append(header.Blocks, block)
+		total += uint64(block.RawSize)
+	}
+}
+
+// readFrameHeader parses the fixed header and validates version and flags.
+func readFrameHeader(r io.Reader) (*FrameHeader, error) {
+	var fixed [frameHeaderSize]byte
+	if _, err := io.ReadFull(r, fixed[:]); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFrameCorrupt, err)
+	}
+	if string(fixed[:4]) != FrameMagic {
+		return nil, ErrFrameMagic
+	}
+
+	header := &FrameHeader{
+		Version: fixed[4],
+		Flags:   fixed[5],
+	}
+	if header.Version != FrameVersion {
+		return nil, fmt.Errorf("%w: %d", ErrFrameVersion, header.Version)
+	}
+	if header.Flags&^frameKnownFlags != 0 {
+		return nil, fmt.Errorf("%w: unknown flags 0x%02x", ErrFrameCorrupt, header.Flags)
+	}
+
+	return header, nil
+}
+
+// readFrameRecord reads the sizes record of block index; end reports the end marker.
+// A raw size the packed size cannot expand to with opts is corrupt, so it is never allocated.
+func readFrameRecord(r io.Reader, index int, opts *Options) (block FrameBlock, end bool, err error) {
+	var record [frameRecordSize]byte
+	if _, err := io.ReadFull(r, record[:]); err != nil {
+		return FrameBlock{}, false, fmt.Errorf("%w: block %d sizes: %w", ErrFrameCorrupt, index, err)
+	}
+
+	block = FrameBlock{
+		RawSize:    binary.LittleEndian.Uint32(record[0:4]),
+		PackedSize: binary.LittleEndian.Uint32(record[4:8]),
+	}
+	if block.RawSize == 0 && block.PackedSize == 0 {
+		return FrameBlock{}, true, nil
+	}
+	if block.RawSize == 0 || block.PackedSize < 4 ||
+		int64(block.RawSize) > MaxDecodedSize(int64(block.PackedSize), opts) {
+		return FrameBlock{}, false, fmt.Errorf("%w: block %d raw=%d packed=%d", ErrFrameCorrupt, index, block.RawSize, block.PackedSize)
+	}
+
+	return block, false, nil
+}
+
+// readFrameEnd reads the original size after the end marker and checks it against total.
+func readFrameEnd(r io.Reader, total uint64) (uint64, error) {
+	var field [8]byte
+	if _, err := io.ReadFull(r, field[:]); err != nil {
+		return 0, fmt.Errorf("%w: end record: %w", ErrFrameCorrupt, err)
+	}
+
+	size := binary.LittleEndian.Uint64(field[:])
+	if size != total {
+		return 0, fmt.Errorf("%w: blocks total=%d size=%d", ErrFrameCorrupt, total, size)
+	}
+
+	return size, nil
+}
+
+// skipFrameBlock skips n bytes of block data.
+func skipFrameBlock(r io.Reader, n int64) error {
+	if s, ok := r.(io.Seeker); ok {
+		if _, err := s.Seek(n, io.SeekCurrent); err == nil {
+			return nil
+		}
+	}
+
+	if _, err := io.CopyN(io.Discard, r, n); err != nil {
+		if errors.Is(err, io.EOF) {
+			return io.ErrUnexpectedEOF
+		}
+
+		return err
+	}
+
+	return nil
+}
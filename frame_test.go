@@ -0,0 +1,169 @@
+package lzss
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestFrameRoundTrip(t *testing.T) {
+	data := bytes.Repeat([]byte("framed lzss payload with repeats "), 200)
+	tests := []struct {
+		opts      *CompressOptions
+		name      string
+		blockSize int
+		blocks    int
+	}{
+		{name: "default single block", blocks: 1},
+		{name: "multi block", blockSize: 1000, blocks: 7},
+		{name: "signed min match 2", opts: &CompressOptions{Checksum: ChecksumSigned, SearchLimit: 512, MinMatchLength: MinMatch2}, blockSize: 4096, blocks: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			fw := NewFrameWriter(&buf, tt.opts)
+			fw.BlockSize = tt.blockSize
+			// Write in uneven chunks to cross block boundaries.
+			for rest := data; len(rest) > 0; {
+				n := min(len(rest), 333)
+				if _, err := fw.Write(rest[:n]); err != nil {
+					t.Fatal(err)
+				}
+				rest = rest[n:]
+			}
+			if tt.blocks > 1 && buf.Len() == 0 {
+				t.Fatal("full blocks not written before Close")
+			}
+			if err := fw.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			fr, err := NewFrameReader(onlyReader{bytes.NewReader(buf.Bytes())})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.opts != nil && fr.Header.Flags != FrameFlagSigned|FrameFlagMinMatch2 {
+				t.Fatalf("flags=0x%02x", fr.Header.Flags)
+			}
+
+			got, err := io.ReadAll(fr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, data) {
+				t.Fatal("round-trip mismatch")
+			}
+			if len(fr.Header.Blocks) != tt.blocks || fr.Header.Size != uint64(len(data)) {
+				t.Fatalf("header=%+v", fr.Header)
+			}
+
+			for _, r := range []io.Reader{bytes.NewReader(buf.Bytes()), onlyReader{bytes.NewReader(buf.Bytes())}} {
+				header, err := ReadFrameHeader(r)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(header.Blocks) != tt.blocks || header.Size != uint64(len(data)) || header.Flags != fr.Header.Flags {
+					t.Fatalf("ReadFrameHeader=%+v", header)
+				}
+			}
+		})
+	}
+}
+
+func TestFrameEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewFrameWriter(&buf, nil).Close(); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != frameHeaderSize+frameEndSize {
+		t.Fatalf("len=%d", buf.Len())
+	}
+
+	fr, err := NewFrameReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(fr)
+	if err != nil || len(got) != 0 {
+		t.Fatalf("got %d bytes, err=%v", len(got), err)
+	}
+}
+
+func TestFrameErrors(t *testing.T) {
+	var buf bytes.Buffer
+	fw := NewFrameWriter(&buf, nil)
+	if _, err := fw.Write([]byte("frame error payload")); err != nil {
+		t.Fatal(err)
+	}
+	if err := fw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fw.Write([]byte("x")); !errors.Is(err, ErrWriterClosed) {
+		t.Fatalf("want ErrWriterClosed, got %v", err)
+	}
+	frame := buf.Bytes()
+
+	if _, err := NewFrameReader(bytes.NewReader([]byte("NOPE0000000000000000"))); !errors.Is(err, ErrFrameMagic) {
+		t.Fatalf("want ErrFrameMagic, got %v", err)
+	}
+
+	badVersion := bytes.Clone(frame)
+	badVersion[4] = 9
+	if _, err := NewFrameReader(bytes.NewReader(badVersion)); !errors.Is(err, ErrFrameVersion) {
+		t.Fatalf("want ErrFrameVersion, got %v", err)
+	}
+
+	badSize := bytes.Clone(frame)
+	binary.LittleEndian.PutUint64(badSize[len(badSize)-8:], 1)
+	if _, err := ReadFrameHeader(bytes.NewReader(badSize)); !errors.Is(err, ErrFrameCorrupt) {
+		t.Fatalf("want ErrFrameCorrupt, got %v", err)
+	}
+	fr, err := NewFrameReader(bytes.NewReader(badSize))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.ReadAll(fr); !errors.Is(err, ErrFrameCorrupt) {
+		t.Fatalf("want ErrFrameCorrupt, got %v", err)
+	}
+
+	if _, err := ReadFrameHeader(bytes.NewReader(frame[:len(frame)-frameEndSize])); !errors.Is(err, ErrFrameCorrupt) {
+		t.Fatalf("missing end record: want ErrFrameCorrupt, got %v", err)
+	}
+
+	badPacked := bytes.Clone(frame)
+	badPacked[frameHeaderSize+4]++
+	fr, err = NewFrameReader(bytes.NewReader(badPacked))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.ReadAll(fr); !errors.Is(err, ErrFrameCorrupt) {
+		t.Fatalf("want ErrFrameCorrupt, got %v", err)
+	}
+
+	// A raw size the block cannot expand to is rejected before allocating it.
+	badRaw := bytes.Clone(frame)
+	binary.LittleEndian.PutUint32(badRaw[frameHeaderSize:], 0xFFFFFFFF)
+	if _, err := ReadFrameHeader(bytes.NewReader(badRaw)); !errors.Is(err, ErrFrameCorrupt) {
+		t.Fatalf("huge raw size: want ErrFrameCorrupt, got %v", err)
+	}
+	fr, err = NewFrameReader(bytes.NewReader(badRaw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.ReadAll(fr); !errors.Is(err, ErrFrameCorrupt) {
+		t.Fatalf("huge raw size: want ErrFrameCorrupt, got %v", err)
+	}
+
+	badData := bytes.Clone(frame)
+	badData[len(badData)-frameEndSize-1] ^= 0xFF
+	fr, err = NewFrameReader(bytes.NewReader(badData))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.ReadAll(fr); !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("want ErrChecksumMismatch, got %v", err)
+	}
+}
@@ -0,0 +1,224 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 Maxim Levchenko (WoozyMasta)
+// Source: github.com/woozymasta/lzss
+
+package lzss
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+)
+
+// FileSpec describes how to decode one compressed file.
+type FileSpec struct {
+	// Options for decoding; nil means DefaultOptions.
+	Options *Options
+	// OutLen is the decoded size of the file.
+	OutLen int
+}
+
+// SizeResolver returns FileSpec for a file path in the underlying fs.FS.
+// ok=false means the file is not compressed and is served as is.
+type SizeResolver func(name string) (spec FileSpec, ok bool, err error)
+
+// FS exposes LZSS-compressed files of an underlying fs.FS as decoded files.
+// Files are decoded through a streaming Reader on Read; Stat and ReadDir report decoded sizes.
+type FS struct {
+	base    fs.FS
+	resolve SizeResolver
+}
+
+// NewFS returns FS over base using resolve to find compressed files and their decoded sizes.
+func NewFS(base fs.FS, resolve SizeResolver) *FS {
+	return &FS{base: base, resolve: resolve}
+}
+
+// Open implements fs.FS.
+func (fsys *FS) Open(name string) (fs.File, error) {
+	f, err := fsys.base.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	if info.IsDir() {
+		dir, ok := f.(fs.ReadDirFile)
+		if !ok {
+			_ = f.Close()
+			return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+		}
+
+		return &fsDir{ReadDirFile: dir, fsys: fsys, name: name}, nil
+	}
+
+	spec, ok, err := fsys.spec(name)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	if !ok {
+		return f, nil
+	}
+
+	reader, err := NewReader(f, spec.OutLen, spec.Options)
+	if err != nil {
+		_ = f.Close()
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	return &fsFile{file: f, reader: reader, info: &fsFileInfo{FileInfo: info, size: int64(spec.OutLen)}, name: name}, nil
+}
+
+// spec resolves name and wraps resolver errors.
+func (fsys *FS) spec(name string) (FileSpec, bool, error) {
+	if fsys.resolve == nil {
+		return FileSpec{}, false, nil
+	}
+
+	spec, ok, err := fsys.resolve(name)
+	if err != nil {
+		return FileSpec{}, false, &fs.PathError{Op: "resolve", Path: name, Err: err}
+	}
+	if ok && spec.OutLen < 0 {
+		return FileSpec{}, false, &fs.PathError{Op: "resolve", Path: name, Err: ErrNegativeOutLen}
+	}
+
+	return spec, ok, nil
+}
+
+// fsFile is an opened compressed file.
+type fsFile struct {
+	file   fs.File
+	reader *Reader
+	info   *fsFileInfo
+	name   string
+}
+
+func (f *fsFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *fsFile) Close() error               { return f.file.Close() }
+
+// Read decodes the next bytes of the file.
+func (f *fsFile) Read(p []byte) (int, error) {
+	n, err := f.reader.Read(p)
+	if err != nil && err != io.EOF {
+		err = &fs.PathError{Op: "read", Path: f.name, Err: err}
+	}
+
+	return n, err
+}
+
+// fsFileInfo reports the decoded size of a compressed file.
+type fsFileInfo struct {
+	fs.FileInfo
+	size int64
+}
+
+func (fi *fsFileInfo) Size() int64 { return fi.size }
+
+// fsDirEntry reports the decoded size of a compressed file in a directory listing.
+type fsDirEntry struct {
+	fs.DirEntry
+	info fs.FileInfo
+}
+
+func (e *fsDirEntry) Info() (fs.FileInfo, error) { return e.info, nil }
+
+// fsDir is an opened directory whose entries report decoded sizes.
+type fsDir struct {
+	fs.ReadDirFile
+	fsys *FS
+	name string
+}
+
+// ReadDir implements fs.ReadDirFile.
+func (d *fsDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	entries, err := d.ReadDirFile.ReadDir(n)
+	for i, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		spec, ok, specErr := d.fsys.spec(path.Join(d.name, entry.Name()))
+		if specErr != nil {
+			return entries[:i], specErr
+		}
+		if !ok {
+			continue
+		}
+
+		info, infoErr := entry.Info()
+		if infoErr != nil {
+			return entries[:i], infoErr
+		}
+		entries[i] = &fsDirEntry{DirEntry: entry, info: &fsFileInfo{FileInfo: info, size: int64(spec.OutLen)}}
+	}
+
+	return entries, err
+}
+
+// Manifest maps file paths to decode parameters; it is the JSON document read by ManifestResolver.
+type Manifest struct {
+	Files map[string]ManifestEntry `json:"files"`
+}
+
+// ManifestEntry describes one compressed file in a Manifest.
+type ManifestEntry struct {
+	Checksum string `json:"checksum,omitempty"`  // "unsigned" (default) or "signed".
+	Size     int    `json:"size"`                // Decoded size.
+	MinMatch int    `json:"min_match,omitempty"` // 3 (default) or 2.
+	Lenient  bool   `json:"lenient,omitempty"`   // Ignore checksum mismatch.
+	Strict   bool   `json:"strict,omitempty"`    // Enable Options.Strict.
+}
+
+// Options returns decode options for the entry.
+func (e ManifestEntry) Options() (*Options, error) {
+	opts := &Options{VerifyChecksum: !e.Lenient, MinMatchLength: e.MinMatch, Strict: e.Strict}
+	switch e.Checksum {
+	case "", "unsigned":
+	case "signed":
+		opts.Checksum = ChecksumSigned
+	default:
+		return nil, fmt.Errorf("%w: checksum %q", ErrInvalidManifest, e.Checksum)
+	}
+	if e.MinMatch != 0 && e.MinMatch != MinMatchDefault && e.MinMatch != MinMatch2 {
+		return nil, fmt.Errorf("%w: min_match %d", ErrInvalidManifest, e.MinMatch)
+	}
+
+	return opts, nil
+}
+
+// ManifestResolver reads a JSON Manifest from fsys and returns a SizeResolver for the listed files.
+// Paths in the manifest are fs paths relative to the root of fsys.
+func ManifestResolver(fsys fs.FS, name string) (SizeResolver, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidManifest, err)
+	}
+
+	specs := make(map[string]FileSpec, len(manifest.Files))
+	for file, entry := range manifest.Files {
+		opts, err := entry.Options()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		specs[path.Clean(file)] = FileSpec{Options: opts, OutLen: entry.Size}
+	}
+
+	return func(name string) (FileSpec, bool, error) {
+		spec, ok := specs[name]
+		return spec, ok, nil
+	}, nil
+}
@@ -0,0 +1,94 @@
+package lzss
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+)
+
+// compressedMapFS returns a MapFS with compressed files, a plain file and a manifest.
+func compressedMapFS(t *testing.T) (fstest.MapFS, map[string][]byte) {
+	t.Helper()
+
+	plain := map[string][]byte{
+		"config.cpp":          bytes.Repeat([]byte("class Cfg {};\n"), 50),
+		"textures/signed.bin": bytes.Repeat([]byte{0xF0, 0x80, 0x7F}, 300),
+	}
+	encCfg, err := Compress(plain["config.cpp"], nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encTex, err := Compress(plain["textures/signed.bin"], &CompressOptions{Checksum: ChecksumSigned, SearchLimit: 4096, MinMatchLength: MinMatch2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	manifest := `{"files": {
+		"config.cpp": {"size": 700},
+		"textures/signed.bin": {"size": 900, "checksum": "signed", "min_match": 2}
+	}}`
+	plain["readme.txt"] = []byte("stored as is")
+	plain["manifest.json"] = []byte(manifest)
+
+	return fstest.MapFS{
+		"config.cpp":          {Data: encCfg},
+		"textures/signed.bin": {Data: encTex},
+		"readme.txt":          {Data: plain["readme.txt"]},
+		"manifest.json":       {Data: []byte(manifest)},
+	}, plain
+}
+
+func TestFS(t *testing.T) {
+	base, plain := compressedMapFS(t)
+	resolve, err := ManifestResolver(base, "manifest.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fsys := NewFS(base, resolve)
+
+	if err := fstest.TestFS(fsys, "config.cpp", "textures/signed.bin", "readme.txt", "manifest.json"); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, want := range plain {
+		got, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("%s: content mismatch", name)
+		}
+	}
+
+	info, err := fs.Stat(fsys, "textures/signed.bin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() != 900 {
+		t.Fatalf("size=%d", info.Size())
+	}
+}
+
+func TestFSDecodeError(t *testing.T) {
+	base, _ := compressedMapFS(t)
+	fsys := NewFS(base, func(name string) (FileSpec, bool, error) {
+		// Wrong size: decoder reads past the block and fails on checksum or EOF.
+		return FileSpec{OutLen: 701}, name == "config.cpp", nil
+	})
+
+	_, err := fs.ReadFile(fsys, "config.cpp")
+	var pathErr *fs.PathError
+	if !errors.As(err, &pathErr) || pathErr.Path != "config.cpp" {
+		t.Fatalf("want *fs.PathError, got %v", err)
+	}
+	var decErr *DecodeError
+	if !errors.As(err, &decErr) {
+		t.Fatalf("want *DecodeError in chain, got %v", err)
+	}
+
+	if _, err := ManifestResolver(fstest.MapFS{"m.json": {Data: []byte(`{"files":{"a":{"size":1,"checksum":"crc"}}}`)}}, "m.json"); !errors.Is(err, ErrInvalidManifest) {
+		t.Fatalf("want ErrInvalidManifest, got %v", err)
+	}
+}
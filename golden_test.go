@@ -0,0 +1,107 @@
+package lzss
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/rand/v2"
+	"testing"
+)
+
+// goldenCorpus returns fixed inputs covering text, runs, noise and mixed data.
+func goldenCorpus() map[string][]byte {
+	rng := rand.New(rand.NewPCG(44, 44))
+	noise := make([]byte, 20000)
+	for i := range noise {
+		noise[i] = byte(rng.Uint32())
+	}
+
+	// Short random words from a small alphabet with copied spans, so matches of all lengths and offsets occur.
+	var mixed []byte
+	for len(mixed) < 30000 {
+		n := 1 + rng.IntN(24)
+		for range n {
+			mixed = append(mixed, "abcdefgh \n\x00\xff"[rng.IntN(12)])
+		}
+		if rng.IntN(4) == 0 && len(mixed) > 5000 {
+			start := rng.IntN(len(mixed) - 4096)
+			mixed = append(mixed, mixed[start:start+rng.IntN(40)]...)
+		}
+	}
+
+	return map[string][]byte{
+		"text":  bytes.Repeat([]byte("class CfgPatches { units[] = {}; weapons[] = {}; };\n"), 400),
+		"runs":  append(bytes.Repeat([]byte{0}, 5000), bytes.Repeat([]byte("ab"), 3000)...),
+		"noise": noise,
+		"mixed": mixed,
+		"tiny":  []byte("ab"),
+	}
+}
+
+// goldenOptions are the option sets of TestCompressGolden.
+var goldenOptions = map[string]*CompressOptions{
+	"default":  nil,
+	"literals": {SearchLimit: 0},
+	"search64": {SearchLimit: 64},
+	"max":      {SearchLimit: 4096},
+	"max_mm2":  {SearchLimit: 4095, MinMatchLength: MinMatch2},
+	"signed":   {Checksum: ChecksumSigned, SearchLimit: 1000},
+}
+
+// goldenOutputs are Compress size, Estimate and SHA-256 prefix of Compress output, recorded with the
+// byte-by-byte match finder before matchLen; output must not change with match finder tuning.
+var goldenOutputs = []struct {
+	corpus, opts string
+	size, est    int
+	sum          string
+}{
+	{"mixed", "default", 22041, 22043, "f1534320a24d18c7"},
+	{"mixed", "literals", 33774, 33774, "8099b9999ad9e56f"},
+	{"mixed", "max", 20347, 20362, "942ad40a46a4148f"},
+	{"mixed", "max_mm2", 20703, 24945, "03462b0a38755cb3"},
+	{"mixed", "search64", 32483, 32483, "43b5f97b5449ed5b"},
+	{"mixed", "signed", 24503, 24503, "d44a9b68135cd205"},
+	{"noise", "default", 22502, 22502, "3c0a8cc9bd560dc2"},
+	{"noise", "literals", 22504, 22504, "2389f4c0d6d39033"},
+	{"noise", "max", 22501, 22501, "0e5db2889a165f30"},
+	{"noise", "max_mm2", 22368, 22368, "ac7d81198c2a38c4"},
+	{"noise", "search64", 22504, 22504, "2389f4c0d6d39033"},
+	{"noise", "signed", 22503, 22503, "56883389933d2f3a"},
+	{"runs", "default", 1308, 1308, "249b00d53a774dd4"},
+	{"runs", "literals", 12379, 12379, "250eceea19ad71be"},
+	{"runs", "max", 1308, 1308, "249b00d53a774dd4"},
+	{"runs", "max_mm2", 1384, 1384, "fe39324d33ba21df"},
+	{"runs", "search64", 1308, 1308, "249b00d53a774dd4"},
+	{"runs", "signed", 1308, 1308, "249b00d53a774dd4"},
+	{"text", "default", 2504, 2504, "3078c3a09ea486d1"},
+	{"text", "literals", 23404, 23404, "d7db2e6eb3f9c3e2"},
+	{"text", "max", 2504, 2504, "3078c3a09ea486d1"},
+	{"text", "max_mm2", 2648, 2648, "9708926f4c841610"},
+	{"text", "search64", 2504, 2504, "3078c3a09ea486d1"},
+	{"text", "signed", 2504, 2504, "3078c3a09ea486d1"},
+	{"tiny", "default", 7, 7, "0861cb7d0e2eafa9"},
+	{"tiny", "literals", 7, 7, "0861cb7d0e2eafa9"},
+	{"tiny", "max", 7, 7, "0861cb7d0e2eafa9"},
+	{"tiny", "max_mm2", 7, 7, "0861cb7d0e2eafa9"},
+	{"tiny", "search64", 7, 7, "0861cb7d0e2eafa9"},
+	{"tiny", "signed", 7, 7, "0861cb7d0e2eafa9"},
+}
+
+func TestCompressGolden(t *testing.T) {
+	corpus := goldenCorpus()
+	for _, g := range goldenOutputs {
+		data, opts := corpus[g.corpus], goldenOptions[g.opts]
+		enc, err := Compress(data, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sum := sha256.Sum256(enc)
+		if len(enc) != g.size || hex.EncodeToString(sum[:8]) != g.sum {
+			t.Fatalf("%s/%s: size=%d sum=%x, want %d %s", g.corpus, g.opts, len(enc), sum[:8], g.size, g.sum)
+		}
+		if est := Estimate(data, opts); est != g.est {
+			t.Fatalf("%s/%s: estimate=%d want=%d", g.corpus, g.opts, est, g.est)
+		}
+	}
+}
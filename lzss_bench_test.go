@@ -3,6 +3,7 @@ package lzss
 import (
 	"bytes"
 	"fmt"
+	"math/rand/v2"
 	"testing"
 )
 
@@ -45,3 +46,65 @@ func BenchmarkDecompress(b *testing.B) {
 		_, _ = Decompress(enc, len(data), nil)
 	}
 }
+
+// benchBlock is a compressed block with its decoded size.
+type benchBlock struct {
+	name   string
+	enc    []byte
+	outLen int
+}
+
+// benchDecodeBlocks returns compressed blocks dominated by matches, literals and runs.
+func benchDecodeBlocks(b *testing.B) []benchBlock {
+	b.Helper()
+
+	noise := make([]byte, 64<<10)
+	rng := rand.New(rand.NewPCG(1, 1))
+	for i := range noise {
+		noise[i] = byte(rng.Uint32())
+	}
+
+	blocks := []benchBlock{
+		{name: "Text", outLen: len(benchInput)},
+		{name: "Literals", outLen: len(noise)},
+		{name: "Runs", outLen: 64 << 10},
+	}
+	inputs := [][]byte{benchInput, noise, bytes.Repeat([]byte("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaab"), 2048)}
+	for i := range blocks {
+		enc, err := Compress(inputs[i], DefaultCompressOptions())
+		if err != nil {
+			b.Fatal(err)
+		}
+		blocks[i].enc = enc
+	}
+
+	return blocks
+}
+
+// BenchmarkDecodePaths compares the slice decoder used by Decompress
+// with the byte-reader decoder used by DecompressFromReader on the same blocks.
+func BenchmarkDecodePaths(b *testing.B) {
+	for _, block := range benchDecodeBlocks(b) {
+		b.Run(block.name+"/Slice", func(b *testing.B) {
+			b.SetBytes(int64(block.outLen))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := Decompress(block.enc, block.outLen, nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(block.name+"/ByteReader", func(b *testing.B) {
+			b.SetBytes(int64(block.outLen))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				r := &sliceByteReader{data: block.enc}
+				if _, _, err := decompressFromByteReader(r, block.outLen, nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
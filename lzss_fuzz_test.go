@@ -0,0 +1,197 @@
+package lzss
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+)
+
+// maxFuzzOutLen bounds output allocation for arbitrary outLen values;
+// outLen % maxFuzzOutLen keeps zero and negative values.
+const maxFuzzOutLen = 1 << 16
+
+// onlyReader hides io.ByteReader and io.Seeker so the unbuffered stream path is used.
+type onlyReader struct {
+	r io.Reader
+}
+
+func (r onlyReader) Read(p []byte) (int, error) {
+	return r.r.Read(p)
+}
+
+// fuzzOptions derives decode options from a mode byte:
+// bit 0 signed checksum, bit 1 min match 2, bit 2 verify checksum, bit 3 strict.
+func fuzzOptions(mode byte) *Options {
+	opts := &Options{
+		VerifyChecksum: mode&0x04 != 0,
+		Strict:         mode&0x08 != 0,
+	}
+	if mode&0x01 != 0 {
+		opts.Checksum = ChecksumSigned
+	}
+	if mode&0x02 != 0 {
+		opts.MinMatchLength = MinMatch2
+	}
+
+	return opts
+}
+
+func addDecodeSeeds(f *testing.F) {
+	f.Helper()
+
+	enc, err := Compress([]byte("fuzz seed payload, fuzz seed payload"), nil)
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(enc, 36, byte(0x04))
+	f.Add([]byte{}, 0, byte(0))
+	f.Add([]byte{0, 0, 0, 0}, 0, byte(0x04))
+	f.Add(enc, -36, byte(0))
+	f.Add([]byte{0x00, 0x01, 0x00, 0x60, 0, 0, 0}, 3, byte(0x04))
+	f.Add([]byte{0x01, 'a', 0x00, 0x00, 0x61, 0, 0, 0}, 4, byte(0x0C))
+}
+
+func FuzzDecompress(f *testing.F) {
+	addDecodeSeeds(f)
+
+	f.Fuzz(func(t *testing.T, src []byte, outLen int, mode byte) {
+		outLen %= maxFuzzOutLen
+		opts := fuzzOptions(mode)
+
+		out, err := Decompress(src, outLen, opts)
+		if err == nil && len(out) != outLen {
+			t.Fatalf("len(out)=%d outLen=%d", len(out), outLen)
+		}
+		if outLen < 0 && len(src) >= 4 && !errors.Is(err, ErrNegativeOutLen) {
+			t.Fatalf("outLen=%d err=%v", outLen, err)
+		}
+
+		out, consumed, err := DecompressFromReader(bytes.NewReader(src), outLen, opts)
+		if err == nil && (len(out) != outLen || consumed > int64(len(src))) {
+			t.Fatalf("len(out)=%d consumed=%d", len(out), consumed)
+		}
+	})
+}
+
+func FuzzRoundTrip(f *testing.F) {
+	f.Add([]byte("a"), uint16(2048))
+	f.Add(bytes.Repeat([]byte("a"), 128), uint16(4096))
+	f.Add(bytes.Repeat([]byte("ab"), 64), uint16(16))
+	f.Add(bytes.Repeat([]byte{0}, 5000), uint16(1))
+	f.Add([]byte("Lorem ipsum dolor sit amet, Lorem ipsum dolor sit amet"), uint16(0))
+
+	f.Fuzz(func(t *testing.T, data []byte, searchLimit uint16) {
+		if len(data) == 0 {
+			return
+		}
+
+		for _, checksum := range []ChecksumMode{ChecksumUnsigned, ChecksumSigned} {
+			for _, minMatch := range []int{0, MinMatchDefault, MinMatch2} {
+				copts := &CompressOptions{Checksum: checksum, SearchLimit: int(searchLimit), MinMatchLength: minMatch}
+				enc, err := Compress(data, copts)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				dopts := &Options{Checksum: checksum, VerifyChecksum: true, MinMatchLength: minMatch, Strict: true}
+				dec, err := Decompress(enc, len(data), dopts)
+				if err != nil {
+					t.Fatalf("opts=%+v: %v", copts, err)
+				}
+				if !bytes.Equal(dec, data) {
+					t.Fatalf("opts=%+v: round-trip mismatch", copts)
+				}
+			}
+		}
+	})
+}
+
+func FuzzStreamMatchesSlice(f *testing.F) {
+	addDecodeSeeds(f)
+
+	f.Fuzz(func(t *testing.T, src []byte, outLen int, mode byte) {
+		if len(src) < 4 {
+			return
+		}
+		outLen %= maxFuzzOutLen
+		opts := fuzzOptions(mode)
+
+		sliceOut, sliceInfo, sliceErr := DecompressBlockInfo(src, outLen, opts)
+		streamOut, streamInfo, streamErr := DecompressFromReaderInfo(onlyReader{bytes.NewReader(src)}, outLen, opts)
+		if readerOut, readerInfo, readerErr := readAllReader(src, outLen, opts); sliceErr == nil {
+			if readerErr != nil || !bytes.Equal(readerOut, sliceOut) || readerInfo != sliceInfo {
+				t.Fatalf("reader err=%v info=%+v slice info=%+v", readerErr, readerInfo, sliceInfo)
+			}
+		} else if readerErr == nil || readerErr.Error() != sliceErr.Error() {
+			t.Fatalf("reader err=%v slice err=%v", readerErr, sliceErr)
+		}
+
+		if (sliceErr == nil) != (streamErr == nil) {
+			t.Fatalf("slice err=%v stream err=%v", sliceErr, streamErr)
+		}
+		if sliceErr != nil {
+			// Decoding errors are *DecodeError on both paths; argument errors are bare sentinels.
+			var sliceDecErr, streamDecErr *DecodeError
+			if errors.As(sliceErr, &sliceDecErr) != errors.As(streamErr, &streamDecErr) {
+				t.Fatalf("slice err=%T stream err=%T", sliceErr, streamErr)
+			}
+			if sliceErr.Error() != streamErr.Error() || sliceInfo.Consumed != streamInfo.Consumed {
+				t.Fatalf("slice err=%v consumed=%d stream err=%v consumed=%d",
+					sliceErr, sliceInfo.Consumed, streamErr, streamInfo.Consumed)
+			}
+
+			return
+		}
+
+		if !bytes.Equal(sliceOut, streamOut) {
+			t.Fatal("decoded output differs")
+		}
+		if sliceInfo != streamInfo {
+			t.Fatalf("slice info=%+v stream info=%+v", sliceInfo, streamInfo)
+		}
+	})
+}
+
+// readAllReader decodes src with the incremental Reader using small reads.
+func readAllReader(src []byte, outLen int, opts *Options) ([]byte, BlockInfo, error) {
+	r, err := NewReader(bytes.NewReader(src), outLen, opts)
+	if err != nil {
+		return nil, BlockInfo{}, err
+	}
+
+	out, err := io.ReadAll(iotest.OneByteReader(r))
+	if err != nil {
+		return nil, r.Info(), err
+	}
+
+	return out, r.Info(), nil
+}
+
+func FuzzBlockSize(f *testing.F) {
+	addDecodeSeeds(f)
+
+	f.Fuzz(func(t *testing.T, src []byte, outLen int, mode byte) {
+		if len(src) < 4 {
+			return
+		}
+		outLen %= maxFuzzOutLen
+		opts := fuzzOptions(mode)
+
+		_, info, decodeErr := DecompressBlockInfo(src, outLen, opts)
+		size, err := BlockSize(src, outLen, opts)
+		if decodeErr == nil || errors.Is(decodeErr, ErrChecksumMismatch) {
+			if err != nil || int64(size) != info.Consumed {
+				t.Fatalf("size=%d err=%v consumed=%d", size, err, info.Consumed)
+			}
+		} else if err == nil || err.Error() != decodeErr.Error() {
+			t.Fatalf("walk err=%v decode err=%v", err, decodeErr)
+		}
+
+		validInfo, validErr := ValidateBlock(src, outLen, opts)
+		if (validErr == nil) != (decodeErr == nil) || (validErr == nil && validInfo != info) {
+			t.Fatalf("validate err=%v info=%+v decode err=%v info=%+v", validErr, validInfo, decodeErr, info)
+		}
+	})
+}
@@ -3,6 +3,7 @@ package lzss
 import (
 	"bytes"
 	"errors"
+	"math/rand/v2"
 	"testing"
 )
 
@@ -345,3 +346,451 @@ func TestDecompressUntilEOFNilProvider(t *testing.T) {
 		t.Fatalf("want ErrNilOutLenProvider, got %v", err)
 	}
 }
+
+func TestChecksumErrorStrict(t *testing.T) {
+	raw := []byte("checksum error payload")
+	enc, err := Compress(raw, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc[len(enc)-4] ^= 0x01
+
+	_, info, err := DecompressBlockInfo(enc, len(raw), nil)
+	if !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("want ErrChecksumMismatch, got %v", err)
+	}
+	var csErr *ChecksumError
+	if !errors.As(err, &csErr) {
+		t.Fatalf("want *ChecksumError, got %T", err)
+	}
+	if csErr.Computed != uint32(sumUnsigned(raw)) || csErr.Stored != csErr.Computed^0x01 {
+		t.Fatalf("stored=0x%x computed=0x%x", csErr.Stored, csErr.Computed)
+	}
+	if info.ChecksumMatch || info.Consumed != int64(len(enc)) {
+		t.Fatalf("info=%+v", info)
+	}
+}
+
+func TestChecksumInfoLenient(t *testing.T) {
+	raw := []byte("lenient info payload")
+	enc, err := Compress(raw, &CompressOptions{Checksum: ChecksumSigned, SearchLimit: 256})
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc[len(enc)-1] ^= 0x80
+
+	dec, info, err := DecompressFromReaderInfo(bytes.NewReader(enc), len(raw), SignedLenientOptions())
+	if err != nil {
+		t.Fatalf("lenient should not error: %v", err)
+	}
+	if !bytes.Equal(dec, raw) {
+		t.Fatalf("got %q", dec)
+	}
+	if info.ChecksumMatch {
+		t.Fatal("expected mismatch to be reported")
+	}
+	if info.Mode != ChecksumSigned || info.ComputedChecksum != uint32(sumSigned(raw)) {
+		t.Fatalf("info=%+v", info)
+	}
+	if info.StoredChecksum != info.ComputedChecksum^0x80000000 {
+		t.Fatalf("stored=0x%x computed=0x%x", info.StoredChecksum, info.ComputedChecksum)
+	}
+	if !errors.Is(info.ChecksumError(), ErrChecksumMismatch) {
+		t.Fatalf("ChecksumError()=%v", info.ChecksumError())
+	}
+}
+
+func TestDecodeErrorTruncatedBlock(t *testing.T) {
+	raw := []byte("truncated block payload")
+	enc, err := Compress(raw, &CompressOptions{SearchLimit: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Cut inside the second flag group: flag byte + 8 literals + flag byte + 2 literals.
+	_, _, err = DecompressBlock(enc[:12], len(raw), nil)
+	if !errors.Is(err, ErrUnexpectedEOFBit) {
+		t.Fatalf("want ErrUnexpectedEOFBit, got %v", err)
+	}
+	var decErr *DecodeError
+	if !errors.As(err, &decErr) {
+		t.Fatalf("want *DecodeError, got %T", err)
+	}
+	if decErr.Block != 0 || decErr.InOffset != 12 || decErr.OutOffset != 10 || decErr.Bit != 2 || decErr.Flag != 0xFF {
+		t.Fatalf("decode error=%+v", decErr)
+	}
+}
+
+func TestDecodeErrorBlockIndex(t *testing.T) {
+	rawA := []byte("decode error block A")
+	rawB := []byte("decode error block B")
+	encA, err := Compress(rawA, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encB, err := Compress(rawB, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encB[len(encB)-2] ^= 0xFF
+	stream := append(append([]byte{}, encA...), encB...)
+
+	_, _, err = DecompressNFromReader(bytes.NewReader(stream), []int{len(rawA), len(rawB)}, nil)
+	var decErr *DecodeError
+	if !errors.As(err, &decErr) {
+		t.Fatalf("want *DecodeError, got %v", err)
+	}
+	if decErr.Block != 1 || decErr.InOffset != int64(len(stream)) || decErr.OutOffset != len(rawB) || decErr.Bit != -1 {
+		t.Fatalf("decode error=%+v", decErr)
+	}
+	var csErr *ChecksumError
+	if !errors.As(err, &csErr) {
+		t.Fatalf("want *ChecksumError in chain, got %v", err)
+	}
+
+	lengths := []int{len(rawA), len(rawB), 8}
+	index := 0
+	next := func() (int, bool) {
+		if index >= len(lengths) {
+			return 0, false
+		}
+		index++
+
+		return lengths[index-1], true
+	}
+	_, _, err = DecompressUntilEOF(bytes.NewReader(stream), next, &Options{})
+	if !errors.Is(err, ErrUnexpectedEOF) {
+		t.Fatalf("want ErrUnexpectedEOF, got %v", err)
+	}
+	if !errors.As(err, &decErr) || decErr.Block != 2 || decErr.InOffset != int64(len(stream)) {
+		t.Fatalf("decode error=%+v", decErr)
+	}
+}
+
+func TestArgumentErrors(t *testing.T) {
+	short := []byte{1, 2}
+	tests := []struct {
+		want error
+		call func() error
+		name string
+	}{
+		{name: "Decompress", want: ErrInputTooShort, call: func() error {
+			_, err := Decompress(short, 10, nil)
+			return err
+		}},
+		{name: "DecompressBlock", want: ErrInputTooShort, call: func() error {
+			_, _, err := DecompressBlock(short, 10, nil)
+			return err
+		}},
+		{name: "DecompressBlockInfo", want: ErrInputTooShort, call: func() error {
+			_, _, err := DecompressBlockInfo(short, 10, nil)
+			return err
+		}},
+		{name: "BlockSize", want: ErrInputTooShort, call: func() error {
+			_, err := BlockSize(short, 10, nil)
+			return err
+		}},
+		{name: "ValidateBlock", want: ErrInputTooShort, call: func() error {
+			_, err := ValidateBlock(short, 10, nil)
+			return err
+		}},
+		{name: "DecompressBlock negative", want: ErrNegativeOutLen, call: func() error {
+			_, _, err := DecompressBlock([]byte{0xFF, 0, 0, 0, 0}, -1, nil)
+			return err
+		}},
+		{name: "BlockSize negative", want: ErrNegativeOutLen, call: func() error {
+			_, err := BlockSize([]byte{0xFF, 0, 0, 0, 0}, -1, nil)
+			return err
+		}},
+		{name: "NewReader negative", want: ErrNegativeOutLen, call: func() error {
+			_, err := NewReader(bytes.NewReader(short), -1, nil)
+			return err
+		}},
+		{name: "NewReader nil", want: ErrNilReader, call: func() error {
+			_, err := NewReader(nil, 10, nil)
+			return err
+		}},
+		{name: "DecompressFromReader nil", want: ErrNilReader, call: func() error {
+			_, _, err := DecompressFromReader(nil, 10, nil)
+			return err
+		}},
+		{name: "DecompressUntilEOF provider", want: ErrNilOutLenProvider, call: func() error {
+			_, _, err := DecompressUntilEOF(bytes.NewReader(short), nil, nil)
+			return err
+		}},
+	}
+
+	// Argument errors have no decoding position and are returned as bare sentinels.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); err != tt.want {
+				t.Fatalf("want %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestStrictRejectsInvalidStreams(t *testing.T) {
+	tests := []struct {
+		want   error
+		name   string
+		src    []byte
+		outLen int
+	}{
+		// Pointer at pos 0 with offset 1 refers to filler region.
+		{name: "filler", src: []byte{0x00, 0x01, 0x00, 0x60, 0, 0, 0}, outLen: 3, want: ErrFillerRef},
+		// Literal 'a', then pointer with offset 0.
+		{name: "zero offset", src: []byte{0x01, 'a', 0x00, 0x00, 0x61, 0, 0, 0}, outLen: 4, want: ErrZeroOffset},
+		// Literal 'a', then pointer offset 1 length 3 with only 2 bytes left.
+		{name: "overrun", src: []byte{0x01, 'a', 0x01, 0x00, 0x22, 0x01, 0, 0}, outLen: 3, want: ErrMatchOverrun},
+		// One literal, but all flag bits set.
+		{name: "flag bits", src: []byte{0xFF, 'a', 0x61, 0, 0, 0}, outLen: 1, want: ErrFlagBitsBeyondEnd},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Decompress(tt.src, tt.outLen, &Options{}); err != nil {
+				t.Fatalf("non-strict decode: %v", err)
+			}
+
+			_, err := Decompress(tt.src, tt.outLen, &Options{Strict: true})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("want %v, got %v", tt.want, err)
+			}
+			var decErr *DecodeError
+			if !errors.As(err, &decErr) {
+				t.Fatalf("want *DecodeError, got %T", err)
+			}
+		})
+	}
+}
+
+func TestStrictAcceptsCompressOutput(t *testing.T) {
+	inputs := [][]byte{
+		[]byte("x"),
+		[]byte("strict validation of own builds"),
+		bytes.Repeat([]byte("abc"), 100),
+		bytes.Repeat([]byte{0}, 1000),
+	}
+	for _, input := range inputs {
+		for _, minMatch := range []int{MinMatchDefault, MinMatch2} {
+			enc, err := Compress(input, &CompressOptions{SearchLimit: 4096, MinMatchLength: minMatch})
+			if err != nil {
+				t.Fatal(err)
+			}
+			opts := &Options{VerifyChecksum: true, MinMatchLength: minMatch, Strict: true}
+			dec, err := Decompress(enc, len(input), opts)
+			if err != nil {
+				t.Fatalf("len=%d minMatch=%d: %v", len(input), minMatch, err)
+			}
+			if !bytes.Equal(dec, input) {
+				t.Fatalf("len=%d minMatch=%d: round-trip mismatch", len(input), minMatch)
+			}
+		}
+	}
+}
+
+func TestAnalyzeConsistency(t *testing.T) {
+	input := bytes.Repeat([]byte("analyze token mix, analyze token mix! "), 50)
+	for _, opts := range []*CompressOptions{
+		nil,
+		{SearchLimit: 0},
+		{SearchLimit: 64},
+		{SearchLimit: 4096, MinMatchLength: MinMatch2},
+	} {
+		st := Analyze(input, opts)
+		enc, err := Compress(input, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if st.InputSize != len(input) || st.OutputSize != len(enc) {
+			t.Fatalf("sizes: %d/%d", st.InputSize, st.OutputSize)
+		}
+		if st.Literals+st.MatchedBytes() != len(input) {
+			t.Fatalf("literals=%d matched=%d", st.Literals, st.MatchedBytes())
+		}
+		if st.FlagBytes+st.Literals+2*st.Pointers+4 != len(enc) {
+			t.Fatalf("flag=%d literals=%d pointers=%d enc=%d", st.FlagBytes, st.Literals, st.Pointers, len(enc))
+		}
+		if st.Saved() != st.MatchedBytes()-2*st.Pointers {
+			t.Fatalf("saved=%d", st.Saved())
+		}
+
+		offsets := 0
+		for _, n := range st.Offsets {
+			offsets += n
+		}
+		if offsets != st.Pointers || st.MaxOffset > WindowSize-1 {
+			t.Fatalf("offsets=%d pointers=%d max=%d", offsets, st.Pointers, st.MaxOffset)
+		}
+		if opts != nil && opts.SearchLimit == 0 && st.Pointers != 0 {
+			t.Fatal("literals-only must not produce pointers")
+		}
+	}
+
+	if st := Analyze(nil, nil); st.InputSize != 0 || st.Ratio() != 0 {
+		t.Fatalf("empty stats=%+v", st)
+	}
+}
+
+func TestEstimate(t *testing.T) {
+	noise := make([]byte, 80<<10)
+	rng := rand.New(rand.NewPCG(1, 2))
+	for i := range noise {
+		noise[i] = byte(rng.Uint32())
+	}
+	text := bytes.Repeat([]byte("estimate compressed size of repetitive text; "), 1600)
+
+	for _, tc := range []struct {
+		name  string
+		input []byte
+		opts  *CompressOptions
+	}{
+		{"literals", text[:1000], &CompressOptions{SearchLimit: 0}},
+		{"text", text[:30000], nil},
+		{"text_sampled", text, nil},
+		{"text_mm2", text[:30000], &CompressOptions{SearchLimit: 4096, MinMatchLength: MinMatch2}},
+		{"noise", noise[:30000], nil},
+		{"noise_sampled", noise, nil},
+	} {
+		enc, err := Compress(tc.input, tc.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		est := Estimate(tc.input, tc.opts)
+		if tc.opts != nil && tc.opts.SearchLimit == 0 && est != len(enc) {
+			t.Fatalf("%s: literals-only estimate=%d actual=%d", tc.name, est, len(enc))
+		}
+		if diff := est - len(enc); diff < -len(enc)/8 || diff > len(enc)/4 {
+			t.Fatalf("%s: estimate=%d actual=%d", tc.name, est, len(enc))
+		}
+	}
+
+	if Estimate(nil, nil) != 0 {
+		t.Fatal("empty estimate must be 0")
+	}
+}
+
+func TestCompressIfSmaller(t *testing.T) {
+	text := bytes.Repeat([]byte("compress only if it pays off "), 100)
+	enc, ok, err := CompressIfSmaller(text, nil, 0)
+	if err != nil || !ok {
+		t.Fatalf("text: ok=%v err=%v", ok, err)
+	}
+	dec, err := Decompress(enc, len(text), nil)
+	if err != nil || !bytes.Equal(dec, text) {
+		t.Fatalf("round-trip: %v", err)
+	}
+
+	// Required savings larger than achievable.
+	out, ok, err := CompressIfSmaller(text, nil, len(text))
+	if err != nil || ok || !bytes.Equal(out, text) {
+		t.Fatalf("min savings: ok=%v err=%v", ok, err)
+	}
+
+	noise := make([]byte, 8192)
+	rng := rand.New(rand.NewPCG(3, 4))
+	for i := range noise {
+		noise[i] = byte(rng.Uint32())
+	}
+	out, ok, err = CompressIfSmaller(noise, nil, 0)
+	if err != nil || ok || !bytes.Equal(out, noise) {
+		t.Fatalf("noise: ok=%v err=%v", ok, err)
+	}
+
+	if _, _, err := CompressIfSmaller(nil, nil, 0); !errors.Is(err, ErrEmptyInput) {
+		t.Fatalf("empty: %v", err)
+	}
+}
+
+func TestCompressOverlappingMatches(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		input   []byte
+		maxSize int // Upper bound for compressed size.
+		maxOff  int // Upper bound for pointer offsets.
+	}{
+		// 1 literal, 7 pointers of 18 bytes, 1 literal: 2 flag bytes + 2 + 14 + 4 checksum.
+		{"run_128", bytes.Repeat([]byte("a"), 128), 22, 1},
+		{"zeros_64k", make([]byte, 64<<10), (64<<10)*2125/18000 + 16, 1},
+		{"pattern_ab", bytes.Repeat([]byte("ab"), 500), 1000*2125/18000 + 16, 2},
+		{"pattern_abc", bytes.Repeat([]byte("abc"), 500), 1500*2125/18000 + 16, 3},
+		{"pattern_7", bytes.Repeat([]byte("0123456"), 300), 2100*2125/18000 + 16, 7},
+	} {
+		enc, err := Compress(tc.input, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(enc) > tc.maxSize {
+			t.Fatalf("%s: compressed=%d want<=%d", tc.name, len(enc), tc.maxSize)
+		}
+
+		st := Analyze(tc.input, nil)
+		if st.MaxOffset > tc.maxOff {
+			t.Fatalf("%s: max offset=%d want<=%d", tc.name, st.MaxOffset, tc.maxOff)
+		}
+
+		dec, err := Decompress(enc, len(tc.input), &Options{Checksum: ChecksumUnsigned, VerifyChecksum: true, Strict: true})
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if !bytes.Equal(dec, tc.input) {
+			t.Fatalf("%s: round-trip mismatch", tc.name)
+		}
+	}
+}
+
+func TestCompressMaxOffset(t *testing.T) {
+	// The only match is exactly 4096 bytes back, which 12-bit offsets cannot encode.
+	rng := rand.New(rand.NewPCG(9, 9))
+	input := make([]byte, WindowSize+32)
+	for i := range input {
+		input[i] = byte(rng.Uint32())
+	}
+	copy(input[WindowSize:], input[:32])
+
+	enc, err := Compress(input, &CompressOptions{SearchLimit: WindowSize})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dec, err := Decompress(enc, len(input), &Options{Checksum: ChecksumUnsigned, VerifyChecksum: true, Strict: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(dec, input) {
+		t.Fatal("round-trip mismatch")
+	}
+
+	st := Analyze(input, &CompressOptions{SearchLimit: WindowSize})
+	offsets := 0
+	for _, n := range st.Offsets {
+		offsets += n
+	}
+	if offsets != st.Pointers || st.MaxOffset > WindowSize-1 || st.SearchLimit != WindowSize-1 {
+		t.Fatalf("offsets=%d pointers=%d max=%d limit=%d", offsets, st.Pointers, st.MaxOffset, st.SearchLimit)
+	}
+}
+
+func TestMatchLen(t *testing.T) {
+	rng := rand.New(rand.NewPCG(11, 12))
+	for range 2000 {
+		n := rng.IntN(40)
+		a := make([]byte, n)
+		b := make([]byte, n)
+		for i := range a {
+			a[i] = byte(rng.IntN(2))
+			b[i] = a[i]
+		}
+		want := n
+		if n > 0 && rng.IntN(4) != 0 {
+			want = rng.IntN(n)
+			b[want] ^= 0x80
+		}
+
+		if got := matchLen(a, b); got != want {
+			t.Fatalf("a=%x b=%x got=%d want=%d", a, b, got, want)
+		}
+	}
+}
@@ -28,6 +28,11 @@ type Options struct {
 	//  - 2: nibble + 2 -> length 2..17.
 	// Zero is treated as 3.
 	MinMatchLength int
+	// Strict rejects streams that the reference encoder does not produce:
+	// back-references before output start (filler region) or with zero offset,
+	// matches that overrun outLen and flag bits set beyond the end of output.
+	// Useful to validate own builds; real-world data may rely on filler pre-fill.
+	Strict bool
 }
 
 // DefaultOptions returns options for default behavior: unsigned checksum, strict verification.
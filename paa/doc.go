@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 Maxim Levchenko (WoozyMasta)
+// Source: github.com/woozymasta/lzss
+
+/*
+Package paa parses PAA/PAC texture headers and decodes LZSS:8bit compressed mipmaps.
+
+Layout: uint16 type, TAGG records ("GGAT" + 4-byte name + uint32 size + data),
+uint16 palette triplet count + palette, mipmaps, terminator (width=0, height=0).
+Mipmap: uint16 width, uint16 height, 24-bit little-endian data length, data.
+On DXT types the top bit of width (0x8000) marks an LZO1X compressed mipmap;
+LZO is not decoded and Pixels returns ErrLZO. Non-DXT types (ARGB4444, ARGB1555,
+ARGB8888, AI88) use LZSS: a data length smaller than the raw pixel size marks
+an LZSS block with signed checksum, decoded with lenient verification.
+
+Use Decode(r) to parse a texture and Texture.Pixels or Mipmap.Pixels to get raw
+pixel payloads (DXT blocks or packed ARGB) per mip level.
+
+# Examples
+
+	tex, err := paa.Decode(f)
+	if err != nil {
+		return err
+	}
+
+	levels, err := tex.Pixels()
+	if err != nil {
+		return err
+	}
+	fmt.Println(tex.Type, tex.Mipmaps[0].Width, tex.Mipmaps[0].Height, len(levels[0]))
+*/
+package paa
@@ -0,0 +1,18 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 Maxim Levchenko (WoozyMasta)
+// Source: github.com/woozymasta/lzss
+
+package paa
+
+import "errors"
+
+// Package errors. Use errors.New for static messages, fmt.Errorf when values are needed.
+var (
+	ErrUnknownType   = errors.New("unknown texture type")
+	ErrTagTooLarge   = errors.New("tagg record too large")
+	ErrTruncated     = errors.New("truncated texture data")
+	ErrSizeMismatch  = errors.New("mipmap size mismatch")
+	ErrNoMipmaps     = errors.New("texture has no mipmaps")
+	ErrTrailingBlock = errors.New("trailing bytes after mipmap lzss block")
+	ErrLZO           = errors.New("lzo compressed mipmap not supported")
+)
@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 Maxim Levchenko (WoozyMasta)
+// Source: github.com/woozymasta/lzss
+
+package paa
+
+import "fmt"
+
+// Type is the PAA pixel format tag.
+type Type uint16
+
+// Pixel format constants.
+const (
+	TypeDXT1     Type = 0xFF01
+	TypeDXT2     Type = 0xFF02
+	TypeDXT3     Type = 0xFF03
+	TypeDXT4     Type = 0xFF04
+	TypeDXT5     Type = 0xFF05
+	TypeARGB4444 Type = 0x4444
+	TypeARGB1555 Type = 0x1555
+	TypeARGB8888 Type = 0x8888
+	TypeAI88     Type = 0x8080
+)
+
+// TAGG names as stored after the "GGAT" signature.
+const (
+	TagAverageColor = "CGVA"
+	TagMaxColor     = "CXAM"
+	TagFlags        = "GALF"
+	TagOffsets      = "SFFO"
+	TagSwizzle      = "ZIWS"
+	TagProcedure    = "CORP"
+)
+
+// Format constants.
+const (
+	// tagSignature precedes every TAGG record.
+	tagSignature = "GGAT"
+
+	// compressedFlag is the top bit of mipmap width; on DXT mipmaps it marks LZO compression.
+	compressedFlag = 0x8000
+
+	// maxTagSize limits TAGG payload size.
+	maxTagSize = 1 << 20
+)
+
+// String returns the format name.
+func (t Type) String() string {
+	switch t {
+	case TypeDXT1:
+		return "DXT1"
+	case TypeDXT2:
+		return "DXT2"
+	case TypeDXT3:
+		return "DXT3"
+	case TypeDXT4:
+		return "DXT4"
+	case TypeDXT5:
+		return "DXT5"
+	case TypeARGB4444:
+		return "ARGB4444"
+	case TypeARGB1555:
+		return "ARGB1555"
+	case TypeARGB8888:
+		return "ARGB8888"
+	case TypeAI88:
+		return "AI88"
+	default:
+		return fmt.Sprintf("Type(0x%04x)", uint16(t))
+	}
+}
+
+// Known reports whether t is a known pixel format.
+func (t Type) Known() bool {
+	return t.IsDXT() || t == TypeARGB4444 || t == TypeARGB1555 || t == TypeARGB8888 || t == TypeAI88
+}
+
+// IsDXT reports whether t is a block-compressed DXT format.
+func (t Type) IsDXT() bool {
+	return t >= TypeDXT1 && t <= TypeDXT5
+}
+
+// RawSize returns the uncompressed pixel payload size for a width x height mip level.
+func (t Type) RawSize(width, height int) int {
+	switch {
+	case t == TypeDXT1:
+		return max(1, (width+3)/4) * max(1, (height+3)/4) * 8
+	case t.IsDXT():
+		return max(1, (width+3)/4) * max(1, (height+3)/4) * 16
+	case t == TypeARGB8888:
+		return width * height * 4
+	default:
+		return width * height * 2
+	}
+}
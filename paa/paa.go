@@ -0,0 +1,266 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 Maxim Levchenko (WoozyMasta)
+// Source: github.com/woozymasta/lzss
+
+package paa
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/woozymasta/lzss"
+)
+
+// Tag is one TAGG record.
+type Tag struct {
+	Name string // Name as stored after "GGAT", e.g. TagAverageColor.
+	Data []byte
+}
+
+// Mipmap is one stored mip level.
+type Mipmap struct {
+	Data    []byte // Stored payload: LZO (flagged DXT) or LZSS (short non-DXT) block, raw pixels otherwise.
+	Offset  int64  // Offset of the mipmap header in the texture.
+	Width   int    // Width without the compressed flag.
+	Height  int    // Height in pixels.
+	Flagged bool   // Top bit of stored width was set (LZO on DXT types).
+}
+
+// Texture is a parsed PAA/PAC texture.
+type Texture struct {
+	Tags    []Tag
+	Palette []byte // Palette triplets (3 bytes each), usually empty.
+	Mipmaps []Mipmap
+	Type    Type
+}
+
+// Decode parses a texture from r: type, TAGG records, palette and all mipmaps.
+// Mipmap payloads are kept as stored; use Pixels to decode them.
+func Decode(r io.Reader) (*Texture, error) {
+	br := &countingReader{r: bufio.NewReader(r)}
+
+	typ, err := br.uint16()
+	if err != nil {
+		return nil, err
+	}
+
+	tex := &Texture{Type: Type(typ)}
+	if !tex.Type.Known() {
+		return nil, fmt.Errorf("%w: 0x%04x", ErrUnknownType, typ)
+	}
+
+	if err := tex.readTags(br); err != nil {
+		return nil, err
+	}
+
+	paletteCount, err := br.uint16()
+	if err != nil {
+		return nil, err
+	}
+	tex.Palette, err = br.bytes(int(paletteCount) * 3)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tex.readMipmaps(br); err != nil {
+		return nil, err
+	}
+	if len(tex.Mipmaps) == 0 {
+		return nil, ErrNoMipmaps
+	}
+
+	return tex, nil
+}
+
+// Tag returns data of the first TAGG record with name.
+func (t *Texture) Tag(name string) ([]byte, bool) {
+	for _, tag := range t.Tags {
+		if tag.Name == name {
+			return tag.Data, true
+		}
+	}
+
+	return nil, false
+}
+
+// Pixels returns raw pixel payloads for all mip levels, decoding LZSS ones
+// with signed checksum in lenient mode; LZO mip levels return ErrLZO.
+func (t *Texture) Pixels() ([][]byte, error) {
+	levels := make([][]byte, 0, len(t.Mipmaps))
+	for i := range t.Mipmaps {
+		pixels, err := t.Mipmaps[i].Pixels(t.Type, nil)
+		if err != nil {
+			return levels, fmt.Errorf("mipmap %d: %w", i, err)
+		}
+		levels = append(levels, pixels)
+	}
+
+	return levels, nil
+}
+
+// RawSize returns the uncompressed pixel payload size of the mip level for typ.
+func (m *Mipmap) RawSize(typ Type) int {
+	return typ.RawSize(m.Width, m.Height)
+}
+
+// Compressed reports whether the stored payload is an LZSS block:
+// typ is not DXT and the stored length is smaller than the raw pixel size.
+func (m *Mipmap) Compressed(typ Type) bool {
+	return !typ.IsDXT() && len(m.Data) < m.RawSize(typ)
+}
+
+// LZO reports whether the stored payload is an LZO1X block: typ is DXT and the width flag is set.
+func (m *Mipmap) LZO(typ Type) bool {
+	return typ.IsDXT() && m.Flagged
+}
+
+// Pixels returns the raw pixel payload of the mip level.
+// LZSS payloads are decoded with opts; nil means lzss.SignedLenientOptions.
+// LZO payloads return ErrLZO.
+func (m *Mipmap) Pixels(typ Type, opts *lzss.Options) ([]byte, error) {
+	rawSize := m.RawSize(typ)
+	if m.LZO(typ) {
+		return nil, fmt.Errorf("%w: %s %dx%d", ErrLZO, typ, m.Width, m.Height)
+	}
+	if !m.Compressed(typ) {
+		if len(m.Data) != rawSize {
+			return nil, fmt.Errorf("%w: stored=%d raw=%d", ErrSizeMismatch, len(m.Data), rawSize)
+		}
+
+		return m.Data, nil
+	}
+
+	if opts == nil {
+		opts = lzss.SignedLenientOptions()
+	}
+
+	out, consumed, err := lzss.DecompressBlock(m.Data, rawSize, opts)
+	if err != nil {
+		return nil, err
+	}
+	if consumed != len(m.Data) {
+		return nil, fmt.Errorf("%w: consumed=%d stored=%d", ErrTrailingBlock, consumed, len(m.Data))
+	}
+
+	return out, nil
+}
+
+// readTags reads TAGG records until the next 4 bytes are not the signature.
+func (t *Texture) readTags(br *countingReader) error {
+	for {
+		sig, err := br.r.Peek(len(tagSignature))
+		if err != nil || string(sig) != tagSignature {
+			return nil
+		}
+		if _, err := br.bytes(len(tagSignature)); err != nil {
+			return err
+		}
+
+		name, err := br.bytes(4)
+		if err != nil {
+			return err
+		}
+		size, err := br.uint32()
+		if err != nil {
+			return err
+		}
+		if size > maxTagSize {
+			return fmt.Errorf("%w: %s size=%d", ErrTagTooLarge, name, size)
+		}
+
+		data, err := br.bytes(int(size))
+		if err != nil {
+			return err
+		}
+		t.Tags = append(t.Tags, Tag{Name: string(name), Data: data})
+	}
+}
+
+// readMipmaps reads mipmaps until the zero terminator or end of input.
+func (t *Texture) readMipmaps(br *countingReader) error {
+	for {
+		offset := br.n
+		width, err := br.uint16()
+		if errors.Is(err, ErrTruncated) && br.n == offset {
+			return nil // No terminator; some writers end right after the last mipmap.
+		}
+		if err != nil {
+			return err
+		}
+		height, err := br.uint16()
+		if err != nil {
+			return err
+		}
+		if width == 0 && height == 0 {
+			return nil
+		}
+
+		size, err := br.uint24()
+		if err != nil {
+			return err
+		}
+		data, err := br.bytes(size)
+		if err != nil {
+			return err
+		}
+
+		t.Mipmaps = append(t.Mipmaps, Mipmap{
+			Data:    data,
+			Offset:  offset,
+			Width:   int(width &^ compressedFlag),
+			Height:  int(height),
+			Flagged: width&compressedFlag != 0,
+		})
+	}
+}
+
+// countingReader reads little-endian fields and tracks offset.
+type countingReader struct {
+	r *bufio.Reader
+	n int64
+}
+
+// bytes reads exactly n bytes.
+func (c *countingReader) bytes(n int) ([]byte, error) {
+	buf := make([]byte, n)
+	read, err := io.ReadFull(c.r, buf)
+	c.n += int64(read)
+	if err != nil {
+		return nil, fmt.Errorf("%w at offset %d: %w", ErrTruncated, c.n, err)
+	}
+
+	return buf, nil
+}
+
+// uint16 reads a little-endian uint16.
+func (c *countingReader) uint16() (uint16, error) {
+	buf, err := c.bytes(2)
+	if err != nil {
+		return 0, err
+	}
+
+	return binary.LittleEndian.Uint16(buf), nil
+}
+
+// uint24 reads a little-endian 24-bit length.
+func (c *countingReader) uint24() (int, error) {
+	buf, err := c.bytes(3)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(buf[0]) | int(buf[1])<<8 | int(buf[2])<<16, nil
+}
+
+// uint32 reads a little-endian uint32.
+func (c *countingReader) uint32() (uint32, error) {
+	buf, err := c.bytes(4)
+	if err != nil {
+		return 0, err
+	}
+
+	return binary.LittleEndian.Uint32(buf), nil
+}
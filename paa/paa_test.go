@@ -0,0 +1,162 @@
+package paa
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/woozymasta/lzss"
+)
+
+// testMip describes a mipmap for buildTexture.
+type testMip struct {
+	pixels   []byte
+	width    int
+	height   int
+	compress bool // Store as LZSS block (non-DXT types).
+	lzo      bool // Store as literal-only LZO1X stream with the width flag (DXT types).
+}
+
+// lzoLiterals returns an LZO1X stream of one literal run (up to 238 bytes) and the end marker.
+func lzoLiterals(data []byte) []byte {
+	out := append([]byte{byte(17 + len(data))}, data...)
+
+	return append(out, 0x11, 0x00, 0x00)
+}
+
+// buildTexture assembles a texture with one TAGG record and given mipmaps.
+func buildTexture(t *testing.T, typ Type, mips []testMip) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	_ = binary.Write(&buf, binary.LittleEndian, uint16(typ))
+	buf.WriteString(tagSignature + TagAverageColor)
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(4))
+	buf.Write([]byte{0x80, 0x80, 0x80, 0xFF})
+	_ = binary.Write(&buf, binary.LittleEndian, uint16(0))
+
+	for _, m := range mips {
+		data := m.pixels
+		width := uint16(m.width)
+		switch {
+		case m.compress:
+			enc, err := lzss.Compress(m.pixels, &lzss.CompressOptions{Checksum: lzss.ChecksumSigned, SearchLimit: 4095})
+			if err != nil {
+				t.Fatal(err)
+			}
+			data = enc
+		case m.lzo:
+			data = lzoLiterals(m.pixels)
+			width |= compressedFlag
+		}
+
+		_ = binary.Write(&buf, binary.LittleEndian, width)
+		_ = binary.Write(&buf, binary.LittleEndian, uint16(m.height))
+		buf.Write([]byte{byte(len(data)), byte(len(data) >> 8), byte(len(data) >> 16)})
+		buf.Write(data)
+	}
+	buf.Write([]byte{0, 0, 0, 0, 0, 0})
+
+	return buf.Bytes()
+}
+
+func TestDecodeDXT1Mipmaps(t *testing.T) {
+	mip0 := bytes.Repeat([]byte{0x10, 0x84, 0x00, 0x00, 0xAA, 0xAA, 0xAA, 0xAA}, 4*4)
+	mip1 := bytes.Repeat([]byte{0xFF, 0xFF, 0x00, 0x00, 0x55, 0x55, 0x55, 0x55}, 2*2)
+	mip2 := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	src := buildTexture(t, TypeDXT1, []testMip{
+		{pixels: mip0, width: 16, height: 16, lzo: true},
+		{pixels: mip1, width: 8, height: 8},
+		{pixels: mip2, width: 4, height: 4},
+	})
+
+	tex, err := Decode(bytes.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tex.Type != TypeDXT1 || len(tex.Mipmaps) != 3 {
+		t.Fatalf("type=%v mipmaps=%d", tex.Type, len(tex.Mipmaps))
+	}
+	if avg, ok := tex.Tag(TagAverageColor); !ok || len(avg) != 4 {
+		t.Fatalf("avg color tag=%v ok=%v", avg, ok)
+	}
+	mip := tex.Mipmaps[0]
+	if mip.Width != 16 || !mip.Flagged || !mip.LZO(tex.Type) || mip.Compressed(tex.Type) || tex.Mipmaps[1].LZO(tex.Type) {
+		t.Fatalf("mipmap headers=%+v", mip)
+	}
+
+	// Flagged DXT mipmaps are LZO, not LZSS.
+	if _, err := mip.Pixels(tex.Type, nil); !errors.Is(err, ErrLZO) {
+		t.Fatalf("want ErrLZO, got %v", err)
+	}
+	if _, err := tex.Pixels(); !errors.Is(err, ErrLZO) {
+		t.Fatalf("want ErrLZO, got %v", err)
+	}
+
+	for i, want := range [][]byte{mip1, mip2} {
+		got, err := tex.Mipmaps[i+1].Pixels(tex.Type, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("mip %d: pixels mismatch", i+1)
+		}
+	}
+}
+
+func TestDecodeARGBCompressedBySize(t *testing.T) {
+	pixels := bytes.Repeat([]byte{0x0F, 0xF0}, 16*16)
+	src := buildTexture(t, TypeARGB4444, []testMip{
+		{pixels: pixels, width: 16, height: 16, compress: true},
+	})
+
+	tex, err := Decode(bytes.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tex.Mipmaps[0].Flagged || !tex.Mipmaps[0].Compressed(tex.Type) {
+		t.Fatalf("mipmap=%+v", tex.Mipmaps[0])
+	}
+
+	got, err := tex.Mipmaps[0].Pixels(tex.Type, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, pixels) {
+		t.Fatal("pixels mismatch")
+	}
+
+	// Full-size non-DXT payloads are raw pixels.
+	src = buildTexture(t, TypeARGB4444, []testMip{{pixels: pixels, width: 16, height: 16}})
+	tex, err = Decode(bytes.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tex.Mipmaps[0].Compressed(tex.Type) || tex.Mipmaps[0].LZO(tex.Type) {
+		t.Fatalf("mipmap=%+v", tex.Mipmaps[0])
+	}
+	if got, err := tex.Mipmaps[0].Pixels(tex.Type, nil); err != nil || !bytes.Equal(got, pixels) {
+		t.Fatalf("raw pixels err=%v", err)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	if _, err := Decode(bytes.NewReader([]byte{0x34, 0x12})); !errors.Is(err, ErrUnknownType) {
+		t.Fatalf("want ErrUnknownType, got %v", err)
+	}
+
+	src := buildTexture(t, TypeDXT5, []testMip{{pixels: make([]byte, 16), width: 4, height: 4}})
+	if _, err := Decode(bytes.NewReader(src[:len(src)-10])); !errors.Is(err, ErrTruncated) {
+		t.Fatalf("want ErrTruncated, got %v", err)
+	}
+
+	tex, err := Decode(bytes.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tex.Mipmaps[0].Width = 8
+	if _, err := tex.Pixels(); !errors.Is(err, ErrSizeMismatch) {
+		t.Fatalf("want ErrSizeMismatch, got %v", err)
+	}
+}
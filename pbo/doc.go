@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 Maxim Levchenko (WoozyMasta)
+// Source: github.com/woozymasta/lzss
+
+/*
+Package pbo reads PBO archives (Arma/DayZ/OFP) with LZSS:8bit compressed entries.
+
+Layout: header entries, entry data in header order, optional 0x00 byte + 20-byte SHA1 trailer.
+Header entry: ASCIIZ name, then 5 little-endian uint32: method, original size, reserved, timestamp, data size.
+The first entry may be a product entry (empty name, method "Vers") followed by ASCIIZ key/value
+properties terminated by an empty key. An entry with empty name ends the header.
+Compressed entries (method "Cprs") store an LZSS:8bit block of DataSize bytes that decodes
+to OriginalSize bytes with unsigned checksum.
+
+Use NewReader(r, size) to parse an archive from io.ReaderAt, or OpenReader(name) for a file.
+Reader implements io/fs.FS with forward-slash paths; compressed entries are decoded on Open.
+Use Reader.VerifyChecksum to check the SHA1 trailer.
+Use NewWriter(w) to write an archive; Writer.Policy selects compression options per entry
+and Writer.DecodeOptions sets the dialect they must match.
+Writer spools stored entry data to a temporary file until Close writes the archive.
+
+# Examples
+
+List entries and read one file:
+
+	rc, err := pbo.OpenReader("mod.pbo")
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	prefix, _ := rc.Property("prefix")
+	for _, e := range rc.Entries {
+		fmt.Println(prefix, e.Name, e.OriginalSize)
+	}
+
+	data, err := fs.ReadFile(rc, "config.cpp")
+
+Write an archive compressing scripts and configs when it makes them smaller:
+
+	w := pbo.NewWriter(f)
+	w.Policy = pbo.OnlyIfSmaller(pbo.ByExtension(nil, ".cpp", ".sqf"))
+	_ = w.SetProperty("prefix", "mymod")
+	if err := w.WriteFile("config.cpp", data, time.Now()); err != nil {
+		return err
+	}
+	return w.Close()
+*/
+package pbo
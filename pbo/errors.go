@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 Maxim Levchenko (WoozyMasta)
+// Source: github.com/woozymasta/lzss
+
+package pbo
+
+import "errors"
+
+// Package errors. Use errors.New for static messages, fmt.Errorf when values are needed.
+var (
+	ErrNameTooLong      = errors.New("header string too long")
+	ErrTruncatedHeader  = errors.New("truncated header")
+	ErrDataOutOfRange   = errors.New("entry data out of archive range")
+	ErrEncrypted        = errors.New("encrypted entries are not supported")
+	ErrSizeMismatch     = errors.New("compressed entry size mismatch")
+	ErrChecksumMissing  = errors.New("archive has no sha1 trailer")
+	ErrChecksumMismatch = errors.New("archive sha1 mismatch")
+	ErrInvalidTrailer   = errors.New("invalid sha1 trailer")
+	ErrNegativeSize     = errors.New("archive size must be non-negative")
+	ErrInvalidName      = errors.New("invalid entry name")
+	ErrDuplicateName    = errors.New("duplicate entry name")
+	ErrEntryTooLarge    = errors.New("entry exceeds 4 GiB")
+	ErrWriterClosed     = errors.New("writer is closed")
+	ErrEntryClosed      = errors.New("entry is already finalized")
+	ErrDialectMismatch  = errors.New("compression options differ from archive decode options")
+)
@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 Maxim Levchenko (WoozyMasta)
+// Source: github.com/woozymasta/lzss
+
+package pbo
+
+import (
+	"io/fs"
+	"path"
+	"strings"
+	"time"
+)
+
+// Packing method constants (stored as little-endian uint32).
+const (
+	// MethodUncompressed marks a stored entry.
+	MethodUncompressed uint32 = 0x00000000
+
+	// MethodCompressed ("Cprs") marks an LZSS:8bit compressed entry.
+	MethodCompressed uint32 = 0x43707273
+
+	// MethodVersion ("Vers") marks the product entry followed by properties.
+	MethodVersion uint32 = 0x56657273
+
+	// MethodEncrypted ("Encr") marks an encrypted entry (not supported).
+	MethodEncrypted uint32 = 0x456e6372
+)
+
+// Format constants.
+const (
+	// headerFieldsSize is the size of 5 uint32 fields after entry name.
+	headerFieldsSize = 20
+
+	// checksumSize is the size of the SHA1 trailer.
+	checksumSize = 20
+
+	// maxNameLen limits ASCIIZ strings in the header.
+	maxNameLen = 4096
+)
+
+// Entry is one file header entry.
+type Entry struct {
+	Name         string // Path as stored, with backslash separators.
+	Offset       int64  // Absolute offset of entry data in the archive.
+	Method       uint32 // Packing method (MethodUncompressed, MethodCompressed, ...).
+	OriginalSize uint32 // Unpacked size; zero for stored entries written by some tools.
+	Reserved     uint32 // Reserved field, usually zero.
+	Timestamp    uint32 // Modification time as Unix seconds.
+	DataSize     uint32 // Size of stored data in the archive.
+}
+
+// Property is one key/value pair of the product entry (e.g. "prefix").
+type Property struct {
+	Key   string
+	Value string
+}
+
+// Compressed reports whether entry data is an LZSS block (MethodCompressed).
+// OriginalSize of other entries is not used: tools fill it inconsistently for stored data.
+func (e *Entry) Compressed() bool {
+	return e.Method == MethodCompressed
+}
+
+// Size returns unpacked entry size.
+func (e *Entry) Size() int64 {
+	if e.Compressed() {
+		return int64(e.OriginalSize)
+	}
+
+	return int64(e.DataSize)
+}
+
+// ModTime returns Timestamp as time.Time.
+func (e *Entry) ModTime() time.Time {
+	return time.Unix(int64(e.Timestamp), 0)
+}
+
+// Path returns entry name as an io/fs path (forward slashes, cleaned).
+// It returns false when the name is not a valid fs path.
+func (e *Entry) Path() (string, bool) {
+	name := path.Clean(strings.ReplaceAll(e.Name, "\\", "/"))
+
+	return name, name != "." && fs.ValidPath(name)
+}
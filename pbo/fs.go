@@ -0,0 +1,220 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 Maxim Levchenko (WoozyMasta)
+// Source: github.com/woozymasta/lzss
+
+package pbo
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"time"
+)
+
+// Interface checks.
+var (
+	_ fs.FS         = (*Reader)(nil)
+	_ fs.ReadFileFS = (*Reader)(nil)
+)
+
+// fsTree maps fs paths to entries and synthesized directories.
+type fsTree struct {
+	files map[string]*Entry
+	dirs  map[string][]string // Directory path -> sorted child names.
+}
+
+// newFSTree builds the path tree; entries with invalid or conflicting paths are skipped.
+func newFSTree(r *Reader) *fsTree {
+	t := &fsTree{
+		files: make(map[string]*Entry, len(r.Entries)),
+		dirs:  map[string][]string{".": nil},
+	}
+
+	for _, e := range r.Entries {
+		name, ok := e.Path()
+		if !ok || t.exists(name) || t.hasFileParent(name) {
+			continue
+		}
+
+		t.files[name] = e
+		t.link(name)
+	}
+
+	for dir := range t.dirs {
+		slices.Sort(t.dirs[dir])
+	}
+
+	return t
+}
+
+// exists reports whether name is a known file or directory.
+func (t *fsTree) exists(name string) bool {
+	if _, ok := t.files[name]; ok {
+		return true
+	}
+	_, ok := t.dirs[name]
+
+	return ok
+}
+
+// hasFileParent reports whether any parent of name is a file.
+func (t *fsTree) hasFileParent(name string) bool {
+	for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+		if _, ok := t.files[dir]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// link adds name to its parent directory, creating parents as needed.
+func (t *fsTree) link(name string) {
+	for name != "." {
+		dir := path.Dir(name)
+		_, known := t.dirs[dir]
+		t.dirs[dir] = append(t.dirs[dir], path.Base(name))
+		if known {
+			return
+		}
+		name = dir
+	}
+}
+
+// Open implements fs.FS. Compressed entries are decoded on open.
+func (r *Reader) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	if e, ok := r.fsys.files[name]; ok {
+		info := r.entryInfo(e)
+		if !e.Compressed() && e.Method != MethodEncrypted {
+			return &entryFile{ReadSeeker: r.OpenEntry(e), info: info}, nil
+		}
+
+		data, err := r.ReadEntry(e)
+		if err != nil {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+		}
+
+		return &entryFile{ReadSeeker: bytes.NewReader(data), info: info}, nil
+	}
+
+	if _, ok := r.fsys.dirs[name]; ok {
+		return &dirFile{r: r, name: name, info: dirInfo(name)}, nil
+	}
+
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+// ReadFile implements fs.ReadFileFS.
+func (r *Reader) ReadFile(name string) ([]byte, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrInvalid}
+	}
+
+	e, ok := r.fsys.files[name]
+	if !ok {
+		if _, isDir := r.fsys.dirs[name]; isDir {
+			return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrInvalid}
+		}
+
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrNotExist}
+	}
+
+	data, err := r.ReadEntry(e)
+	if err != nil {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: err}
+	}
+
+	return data, nil
+}
+
+// entryInfo returns fs.FileInfo for an entry.
+func (r *Reader) entryInfo(e *Entry) *fileInfo {
+	name, _ := e.Path()
+
+	return &fileInfo{name: path.Base(name), size: e.Size(), modTime: e.ModTime(), entry: e}
+}
+
+// dirInfo returns fs.FileInfo for a synthesized directory.
+func dirInfo(name string) *fileInfo {
+	return &fileInfo{name: path.Base(name), mode: fs.ModeDir | 0o555}
+}
+
+// fileInfo implements fs.FileInfo and fs.DirEntry.
+type fileInfo struct {
+	modTime time.Time
+	entry   *Entry
+	name    string
+	size    int64
+	mode    fs.FileMode
+}
+
+func (fi *fileInfo) Name() string { return fi.name }
+func (fi *fileInfo) Size() int64  { return fi.size }
+func (fi *fileInfo) Mode() fs.FileMode {
+	if fi.mode == 0 {
+		return 0o444
+	}
+
+	return fi.mode
+}
+func (fi *fileInfo) ModTime() time.Time         { return fi.modTime }
+func (fi *fileInfo) IsDir() bool                { return fi.mode.IsDir() }
+func (fi *fileInfo) Sys() any                   { return fi.entry }
+func (fi *fileInfo) Type() fs.FileMode          { return fi.Mode().Type() }
+func (fi *fileInfo) Info() (fs.FileInfo, error) { return fi, nil }
+func (fi *fileInfo) String() string             { return fs.FormatFileInfo(fi) }
+
+// entryFile is an opened file entry.
+type entryFile struct {
+	io.ReadSeeker
+	info *fileInfo
+}
+
+func (f *entryFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *entryFile) Close() error               { return nil }
+
+// dirFile is an opened directory.
+type dirFile struct {
+	r      *Reader
+	info   *fileInfo
+	name   string
+	offset int
+}
+
+func (d *dirFile) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *dirFile) Close() error               { return nil }
+
+func (d *dirFile) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.name, Err: fs.ErrInvalid}
+}
+
+// ReadDir implements fs.ReadDirFile.
+func (d *dirFile) ReadDir(n int) ([]fs.DirEntry, error) {
+	children := d.r.fsys.dirs[d.name]
+	remaining := children[d.offset:]
+	if n > 0 && len(remaining) == 0 {
+		return nil, io.EOF
+	}
+	if n > 0 && n < len(remaining) {
+		remaining = remaining[:n]
+	}
+
+	entries := make([]fs.DirEntry, 0, len(remaining))
+	for _, child := range remaining {
+		full := path.Join(d.name, child)
+		if e, ok := d.r.fsys.files[full]; ok {
+			entries = append(entries, d.r.entryInfo(e))
+		} else {
+			entries = append(entries, dirInfo(full))
+		}
+	}
+	d.offset += len(remaining)
+
+	return entries, nil
+}
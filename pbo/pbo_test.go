@@ -0,0 +1,409 @@
+package pbo
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/binary"
+	"errors"
+	"io/fs"
+	"math/rand/v2"
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/woozymasta/lzss"
+)
+
+// testEntry describes an entry for buildArchive.
+type testEntry struct {
+	name     string
+	data     []byte
+	compress bool
+}
+
+// buildArchive assembles a PBO with product entry, given entries and SHA1 trailer.
+func buildArchive(t *testing.T, props []Property, entries []testEntry) []byte {
+	t.Helper()
+
+	var header, body bytes.Buffer
+	writeFields := func(method, originalSize, timestamp, dataSize uint32) {
+		var fields [headerFieldsSize]byte
+		binary.LittleEndian.PutUint32(fields[0:4], method)
+		binary.LittleEndian.PutUint32(fields[4:8], originalSize)
+		binary.LittleEndian.PutUint32(fields[12:16], timestamp)
+		binary.LittleEndian.PutUint32(fields[16:20], dataSize)
+		header.Write(fields[:])
+	}
+
+	header.WriteByte(0)
+	writeFields(MethodVersion, 0, 0, 0)
+	for _, p := range props {
+		header.WriteString(p.Key + "\x00" + p.Value + "\x00")
+	}
+	header.WriteByte(0)
+
+	for _, e := range entries {
+		header.WriteString(e.name + "\x00")
+		if !e.compress {
+			writeFields(MethodUncompressed, 0, 1700000000, uint32(len(e.data)))
+			body.Write(e.data)
+			continue
+		}
+
+		enc, err := lzss.Compress(e.data, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		writeFields(MethodCompressed, uint32(len(e.data)), 1700000000, uint32(len(enc)))
+		body.Write(enc)
+	}
+
+	header.WriteByte(0)
+	writeFields(0, 0, 0, 0)
+
+	archive := append(header.Bytes(), body.Bytes()...)
+	sum := sha1.Sum(archive)
+	archive = append(archive, 0)
+
+	return append(archive, sum[:]...)
+}
+
+func testEntries() []testEntry {
+	return []testEntry{
+		{name: "config.cpp", data: []byte("class CfgPatches { class Test { units[] = {}; }; };\n"), compress: true},
+		{name: "data\\readme.txt", data: []byte("stored entry")},
+		{name: "data\\textures\\big.bin", data: bytes.Repeat([]byte("texture payload "), 256), compress: true},
+		{name: "scripts\\empty.c", data: nil},
+	}
+}
+
+func TestReaderParsesHeader(t *testing.T) {
+	props := []Property{{Key: "prefix", Value: "test\\mod"}, {Key: "version", Value: "1"}}
+	archive := buildArchive(t, props, testEntries())
+
+	r, err := NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if prefix, ok := r.Property("prefix"); !ok || prefix != "test\\mod" {
+		t.Fatalf("prefix=%q ok=%v", prefix, ok)
+	}
+	if len(r.Entries) != 4 {
+		t.Fatalf("entries=%d", len(r.Entries))
+	}
+	if !r.Entries[0].Compressed() || r.Entries[1].Compressed() {
+		t.Fatal("unexpected compression flags")
+	}
+	if r.DataEnd() != int64(len(archive)-21) {
+		t.Fatalf("data end=%d", r.DataEnd())
+	}
+
+	for i, want := range testEntries() {
+		got, err := r.ReadEntry(r.Entries[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want.data) {
+			t.Fatalf("%s: content mismatch", want.name)
+		}
+	}
+
+	if err := r.VerifyChecksum(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReaderFS(t *testing.T) {
+	archive := buildArchive(t, nil, testEntries())
+	r, err := NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := fstest.TestFS(r, "config.cpp", "data/readme.txt", "data/textures/big.bin", "scripts/empty.c"); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := fs.ReadFile(r, "data/textures/big.bin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, testEntries()[2].data) {
+		t.Fatal("content mismatch")
+	}
+}
+
+func TestReaderChecksumMismatch(t *testing.T) {
+	archive := buildArchive(t, nil, testEntries())
+	archive[len(archive)-1] ^= 0xFF
+
+	r, err := NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.VerifyChecksum(); !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("want ErrChecksumMismatch, got %v", err)
+	}
+
+	r, err = NewReader(bytes.NewReader(archive[:len(archive)-21]), int64(len(archive)-21))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.VerifyChecksum(); !errors.Is(err, ErrChecksumMissing) {
+		t.Fatalf("want ErrChecksumMissing, got %v", err)
+	}
+}
+
+func TestReaderConsumedMismatch(t *testing.T) {
+	archive := buildArchive(t, nil, testEntries())
+	r, err := NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Pretend the compressed block is one byte longer than it is.
+	e := *r.Entries[0]
+	e.DataSize++
+	if _, err := r.ReadEntry(&e); !errors.Is(err, ErrSizeMismatch) {
+		t.Fatalf("want ErrSizeMismatch, got %v", err)
+	}
+}
+
+func TestReaderOriginalSize(t *testing.T) {
+	archive := buildArchive(t, nil, testEntries())
+	r, err := NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A corrupt size field larger than the block can expand to fails before allocating.
+	e := *r.Entries[0]
+	e.OriginalSize = 0xFFFFFFFF
+	if _, err := r.ReadEntry(&e); !errors.Is(err, ErrSizeMismatch) {
+		t.Fatalf("want ErrSizeMismatch, got %v", err)
+	}
+
+	// Stored entries are read as is whatever OriginalSize says.
+	e = *r.Entries[1]
+	e.OriginalSize = e.DataSize + 100
+	if e.Compressed() {
+		t.Fatal("stored entry reported as compressed")
+	}
+	data, err := r.ReadEntry(&e)
+	if err != nil || string(data) != "stored entry" {
+		t.Fatalf("data=%q err=%v", data, err)
+	}
+}
+
+func TestReaderTruncatedHeader(t *testing.T) {
+	archive := buildArchive(t, nil, testEntries())
+	if _, err := NewReader(bytes.NewReader(archive[:30]), 30); !errors.Is(err, ErrTruncatedHeader) {
+		t.Fatalf("want ErrTruncatedHeader, got %v", err)
+	}
+}
+
+func TestWriterRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.Policy = OnlyIfSmaller(MinSize(16, ByExtension(nil, ".cpp", "bin")))
+	if err := w.SetProperty("prefix", "test\\mod"); err != nil {
+		t.Fatal(err)
+	}
+
+	modTime := time.Unix(1700000000, 0)
+	noise := make([]byte, 512)
+	rng := rand.New(rand.NewPCG(1, 2))
+	for i := range noise {
+		noise[i] = byte(rng.Uint32())
+	}
+	for _, e := range testEntries() {
+		if err := w.WriteFile(e.name, e.data, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fw, err := w.Create("data/noise.bin", modTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fw.Write(noise); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.VerifyChecksum(); err != nil {
+		t.Fatal(err)
+	}
+	if prefix, _ := r.Property("prefix"); prefix != "test\\mod" {
+		t.Fatalf("prefix=%q", prefix)
+	}
+
+	wantCompressed := map[string]bool{
+		"config.cpp":              false, // selected, but too short to shrink
+		"data\\readme.txt":        false,
+		"data\\textures\\big.bin": true,
+		"scripts\\empty.c":        false,
+		"data\\noise.bin":         false,
+	}
+	for _, e := range r.Entries {
+		if e.Compressed() != wantCompressed[e.Name] {
+			t.Fatalf("%s: compressed=%v", e.Name, e.Compressed())
+		}
+		if e.Timestamp != 1700000000 {
+			t.Fatalf("%s: timestamp=%d", e.Name, e.Timestamp)
+		}
+	}
+
+	if err := fstest.TestFS(r, "config.cpp", "data/readme.txt", "data/textures/big.bin", "data/noise.bin"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := fs.ReadFile(r, "data/noise.bin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, noise) {
+		t.Fatal("noise content mismatch")
+	}
+}
+
+func TestWriterSpool(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	for _, e := range testEntries() {
+		if err := w.WriteFile(e.name, e.data, time.Time{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if files, _ := os.ReadDir(tmp); len(files) != 1 {
+		t.Fatalf("spool files=%d", len(files))
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("written before Close: %d", buf.Len())
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if files, _ := os.ReadDir(tmp); len(files) != 0 {
+		t.Fatalf("spool not removed: %d files", len(files))
+	}
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.VerifyChecksum(); err != nil {
+		t.Fatal(err)
+	}
+	for i, e := range testEntries() {
+		data, err := r.ReadEntry(r.Entries[i])
+		if err != nil || !bytes.Equal(data, e.data) {
+			t.Fatalf("%s: err=%v", e.name, err)
+		}
+	}
+
+	// A failing underlying writer still removes the spool.
+	w = NewWriter(failWriter{})
+	if err := w.WriteFile("a.txt", []byte("stored"), time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err == nil {
+		t.Fatal("want write error")
+	}
+	if files, _ := os.ReadDir(tmp); len(files) != 0 {
+		t.Fatalf("spool not removed: %d files", len(files))
+	}
+}
+
+// failWriter fails every write.
+type failWriter struct{}
+
+func (failWriter) Write([]byte) (int, error) { return 0, errors.New("write failed") }
+
+func TestWriterDecodeOptions(t *testing.T) {
+	payload := bytes.Repeat([]byte("abcabd"), 64)
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.DecodeOptions = &lzss.Options{Checksum: lzss.ChecksumSigned, VerifyChecksum: true, MinMatchLength: lzss.MinMatch2}
+	w.Policy = PolicyFunc(func(name string, _ int) Decision {
+		if strings.HasSuffix(name, ".paa") {
+			return Decision{Options: &lzss.CompressOptions{Checksum: lzss.ChecksumSigned, SearchLimit: 4096, MinMatchLength: lzss.MinMatch2}}
+		}
+
+		return Decision{Options: lzss.DefaultCompressOptions()}
+	})
+	if err := w.WriteFile("signed.paa", payload, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteFile("default.txt", payload, time.Time{}); !errors.Is(err, ErrDialectMismatch) {
+		t.Fatalf("want ErrDialectMismatch, got %v", err)
+	}
+	if err := w.WriteFile("SIGNED.PAA", payload, time.Time{}); !errors.Is(err, ErrDuplicateName) {
+		t.Fatalf("want ErrDuplicateName, got %v", err)
+	}
+
+	// Create streams the entry through the spool and compresses it from there.
+	fw, err := w.Create("created.paa", time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 2 {
+		if _, err := fw.Write(payload); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fw.Write(payload); !errors.Is(err, ErrEntryClosed) {
+		t.Fatalf("want ErrEntryClosed, got %v", err)
+	}
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.DecodeOptions = w.DecodeOptions
+	if err := r.VerifyChecksum(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string][]byte{
+		"signed.paa":  payload,
+		"created.paa": bytes.Repeat(payload, 2),
+	}
+	if len(r.Entries) != len(want) {
+		t.Fatalf("entries=%d", len(r.Entries))
+	}
+	for _, e := range r.Entries {
+		data, err := r.ReadEntry(e)
+		if err != nil {
+			t.Fatalf("%s: %v", e.Name, err)
+		}
+		if !e.Compressed() || !bytes.Equal(data, want[e.Name]) {
+			t.Fatalf("%s: compressed=%v content mismatch", e.Name, e.Compressed())
+		}
+	}
+}
+
+func TestPolicyNil(t *testing.T) {
+	if d := MinSize(16, nil).Decide("a.txt", 32); d.Options == nil {
+		t.Fatal("MinSize(nil) stored entry above min size")
+	}
+	if d := MaxSize(16, nil).Decide("a.txt", 8); d.Options == nil {
+		t.Fatal("MaxSize(nil) stored entry below max size")
+	}
+	if d := OnlyIfSmaller(nil).Decide("a.txt", 8); d.Options == nil || !d.OnlyIfSmaller {
+		t.Fatalf("OnlyIfSmaller(nil) decision=%+v", d)
+	}
+}
@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 Maxim Levchenko (WoozyMasta)
+// Source: github.com/woozymasta/lzss
+
+package pbo
+
+import (
+	"path"
+	"strings"
+
+	"github.com/woozymasta/lzss"
+)
+
+// Decision is a compression policy result for one entry.
+type Decision struct {
+	// Options for lzss.Compress (checksum mode, min match, search limit); nil stores the entry.
+	Options *lzss.CompressOptions
+	// OnlyIfSmaller stores the entry when compressed data is not smaller than the original;
+	// WriteFile data estimated as incompressible (lzss.Estimate) is stored without compressing.
+	OnlyIfSmaller bool
+}
+
+// Policy decides per entry whether and how to compress it.
+type Policy interface {
+	// Decide returns the decision for entry name (archive path) with unpacked size.
+	Decide(name string, size int) Decision
+}
+
+// PolicyFunc adapts a function to Policy.
+type PolicyFunc func(name string, size int) Decision
+
+// Decide calls f(name, size).
+func (f PolicyFunc) Decide(name string, size int) Decision {
+	return f(name, size)
+}
+
+// Store returns a policy that stores all entries uncompressed.
+func Store() Policy {
+	return PolicyFunc(func(string, int) Decision {
+		return Decision{}
+	})
+}
+
+// Always returns a policy that compresses all entries with opts (nil means lzss.DefaultCompressOptions).
+func Always(opts *lzss.CompressOptions) Policy {
+	if opts == nil {
+		opts = lzss.DefaultCompressOptions()
+	}
+
+	return PolicyFunc(func(string, int) Decision {
+		return Decision{Options: opts}
+	})
+}
+
+// ByExtension returns a policy that compresses entries with one of exts (e.g. ".cpp", "sqf")
+// using opts, and stores others. Extensions match case-insensitively.
+func ByExtension(opts *lzss.CompressOptions, exts ...string) Policy {
+	if opts == nil {
+		opts = lzss.DefaultCompressOptions()
+	}
+
+	set := make(map[string]struct{}, len(exts))
+	for _, ext := range exts {
+		set[strings.ToLower(strings.TrimPrefix(ext, "."))] = struct{}{}
+	}
+
+	return PolicyFunc(func(name string, _ int) Decision {
+		ext := strings.ToLower(strings.TrimPrefix(path.Ext(strings.ReplaceAll(name, "\\", "/")), "."))
+		if _, ok := set[ext]; ok {
+			return Decision{Options: opts}
+		}
+
+		return Decision{}
+	})
+}
+
+// MinSize returns a policy that stores entries smaller than minSize bytes and delegates others to p.
+// A nil p compresses the others as Always(nil).
+func MinSize(minSize int, p Policy) Policy {
+	if p == nil {
+		p = Always(nil)
+	}
+
+	return PolicyFunc(func(name string, size int) Decision {
+		if size < minSize {
+			return Decision{}
+		}
+
+		return p.Decide(name, size)
+	})
+}
+
+// MaxSize returns a policy that stores entries larger than maxSize bytes and delegates others to p.
+// A nil p compresses the others as Always(nil).
+func MaxSize(maxSize int, p Policy) Policy {
+	if p == nil {
+		p = Always(nil)
+	}
+
+	return PolicyFunc(func(name string, size int) Decision {
+		if size > maxSize {
+			return Decision{}
+		}
+
+		return p.Decide(name, size)
+	})
+}
+
+// OnlyIfSmaller returns a policy that keeps decisions of p but stores entries
+// whose compressed data is not smaller than the original. A nil p is Always(nil).
+func OnlyIfSmaller(p Policy) Policy {
+	if p == nil {
+		p = Always(nil)
+	}
+
+	return PolicyFunc(func(name string, size int) Decision {
+		d := p.Decide(name, size)
+		d.OnlyIfSmaller = true
+
+		return d
+	})
+}
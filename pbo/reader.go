@@ -0,0 +1,288 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 Maxim Levchenko (WoozyMasta)
+// Source: github.com/woozymasta/lzss
+
+package pbo
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1" // #nosec G505 -- PBO trailer format uses SHA1
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/woozymasta/lzss"
+)
+
+// Reader reads entries of a PBO archive.
+type Reader struct {
+	r io.ReaderAt
+
+	// DecodeOptions are used to decode compressed entries; nil means lzss.DefaultOptions.
+	DecodeOptions *lzss.Options
+
+	fsys *fsTree
+
+	// Properties of the product entry in header order.
+	Properties []Property
+	// Entries in header order (product entry and header terminator excluded).
+	Entries []*Entry
+
+	size       int64
+	headerSize int64
+	dataEnd    int64
+}
+
+// ReadCloser is a Reader backed by an opened file.
+type ReadCloser struct {
+	f *os.File
+	Reader
+}
+
+// OpenReader opens the PBO file name and parses its header.
+func OpenReader(name string) (*ReadCloser, error) {
+	f, err := os.Open(name) // #nosec G304 -- caller provides archive path
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	rc := &ReadCloser{f: f}
+	if err := rc.init(f, info.Size()); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	return rc, nil
+}
+
+// Close closes the underlying file.
+func (rc *ReadCloser) Close() error {
+	return rc.f.Close()
+}
+
+// NewReader parses the PBO header from r, which is size bytes long.
+func NewReader(r io.ReaderAt, size int64) (*Reader, error) {
+	reader := &Reader{}
+	if err := reader.init(r, size); err != nil {
+		return nil, err
+	}
+
+	return reader, nil
+}
+
+// init parses the header and computes entry data offsets.
+func (r *Reader) init(ra io.ReaderAt, size int64) error {
+	if size < 0 {
+		return ErrNegativeSize
+	}
+
+	r.r = ra
+	r.size = size
+
+	hr := &headerReader{r: bufio.NewReader(io.NewSectionReader(ra, 0, size))}
+	for first := true; ; first = false {
+		entry, err := hr.readEntry()
+		if err != nil {
+			return err
+		}
+
+		if entry.Name == "" {
+			if first && entry.Method == MethodVersion {
+				props, err := hr.readProperties()
+				if err != nil {
+					return err
+				}
+				r.Properties = props
+				continue
+			}
+
+			break
+		}
+
+		r.Entries = append(r.Entries, entry)
+	}
+
+	r.headerSize = hr.offset
+	offset := hr.offset
+	for _, e := range r.Entries {
+		e.Offset = offset
+		offset += int64(e.DataSize)
+	}
+	if offset > size {
+		return fmt.Errorf("%w: data end=%d archive size=%d", ErrDataOutOfRange, offset, size)
+	}
+	r.dataEnd = offset
+	r.fsys = newFSTree(r)
+
+	return nil
+}
+
+// Property returns the product entry property value for key.
+func (r *Reader) Property(key string) (string, bool) {
+	for _, p := range r.Properties {
+		if p.Key == key {
+			return p.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// HeaderSize returns the size of the header in bytes (offset of the first entry data).
+func (r *Reader) HeaderSize() int64 {
+	return r.headerSize
+}
+
+// DataEnd returns the offset right after the last entry data (start of the SHA1 trailer).
+func (r *Reader) DataEnd() int64 {
+	return r.dataEnd
+}
+
+// OpenEntry returns a reader for raw stored entry data (compressed entries are not decoded).
+func (r *Reader) OpenEntry(e *Entry) *io.SectionReader {
+	return io.NewSectionReader(r.r, e.Offset, int64(e.DataSize))
+}
+
+// ReadEntry returns unpacked entry data, decoding compressed entries.
+// The LZSS block must consume exactly DataSize bytes; OriginalSize above
+// lzss.MaxDecodedSize of DataSize is rejected before allocating.
+func (r *Reader) ReadEntry(e *Entry) ([]byte, error) {
+	if e.Method == MethodEncrypted {
+		return nil, fmt.Errorf("%s: %w", e.Name, ErrEncrypted)
+	}
+
+	section := r.OpenEntry(e)
+	if !e.Compressed() {
+		data := make([]byte, e.DataSize)
+		if _, err := io.ReadFull(section, data); err != nil {
+			return nil, fmt.Errorf("%s: %w", e.Name, err)
+		}
+
+		return data, nil
+	}
+
+	if limit := lzss.MaxDecodedSize(int64(e.DataSize), r.DecodeOptions); int64(e.OriginalSize) > limit {
+		return nil, fmt.Errorf("%s: %w: original size=%d exceeds %d for data size=%d", e.Name, ErrSizeMismatch, e.OriginalSize, limit, e.DataSize)
+	}
+
+	data, consumed, err := lzss.DecompressFromReader(section, int(e.OriginalSize), r.DecodeOptions)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", e.Name, err)
+	}
+	if consumed != int64(e.DataSize) {
+		return nil, fmt.Errorf("%s: %w: consumed=%d data size=%d", e.Name, ErrSizeMismatch, consumed, e.DataSize)
+	}
+
+	return data, nil
+}
+
+// VerifyChecksum checks the SHA1 trailer (0x00 byte + 20-byte SHA1 of all preceding bytes).
+// Archives without trailer (e.g. OFP) return ErrChecksumMissing.
+func (r *Reader) VerifyChecksum() error {
+	trailer := r.size - r.dataEnd
+	if trailer == 0 {
+		return ErrChecksumMissing
+	}
+	if trailer != checksumSize+1 {
+		return fmt.Errorf("%w: %d bytes after data", ErrInvalidTrailer, trailer)
+	}
+
+	var stored [checksumSize + 1]byte
+	if _, err := r.r.ReadAt(stored[:], r.dataEnd); err != nil {
+		return err
+	}
+	if stored[0] != 0 {
+		return fmt.Errorf("%w: marker byte 0x%02x", ErrInvalidTrailer, stored[0])
+	}
+
+	h := sha1.New() // #nosec G401 -- PBO trailer format uses SHA1
+	if _, err := io.Copy(h, io.NewSectionReader(r.r, 0, r.dataEnd)); err != nil {
+		return err
+	}
+	if !bytes.Equal(h.Sum(nil), stored[1:]) {
+		return ErrChecksumMismatch
+	}
+
+	return nil
+}
+
+// headerReader reads header strings and fields and tracks offset.
+type headerReader struct {
+	r      *bufio.Reader
+	offset int64
+}
+
+// readEntry reads one header entry (name and 5 uint32 fields).
+func (h *headerReader) readEntry() (*Entry, error) {
+	name, err := h.readString()
+	if err != nil {
+		return nil, err
+	}
+
+	var fields [headerFieldsSize]byte
+	if _, err := io.ReadFull(h.r, fields[:]); err != nil {
+		return nil, fmt.Errorf("%w: entry %q fields: %w", ErrTruncatedHeader, name, err)
+	}
+	h.offset += headerFieldsSize
+
+	return &Entry{
+		Name:         name,
+		Method:       binary.LittleEndian.Uint32(fields[0:4]),
+		OriginalSize: binary.LittleEndian.Uint32(fields[4:8]),
+		Reserved:     binary.LittleEndian.Uint32(fields[8:12]),
+		Timestamp:    binary.LittleEndian.Uint32(fields[12:16]),
+		DataSize:     binary.LittleEndian.Uint32(fields[16:20]),
+	}, nil
+}
+
+// readProperties reads key/value pairs until an empty key.
+func (h *headerReader) readProperties() ([]Property, error) {
+	var props []Property
+	for {
+		key, err := h.readString()
+		if err != nil {
+			return nil, err
+		}
+		if key == "" {
+			return props, nil
+		}
+
+		value, err := h.readString()
+		if err != nil {
+			return nil, err
+		}
+		props = append(props, Property{Key: key, Value: value})
+	}
+}
+
+// readString reads an ASCIIZ string up to maxNameLen bytes.
+func (h *headerReader) readString() (string, error) {
+	var buf []byte
+	for {
+		b, err := h.r.ReadByte()
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+
+			return "", fmt.Errorf("%w: %w", ErrTruncatedHeader, err)
+		}
+		h.offset++
+
+		if b == 0 {
+			return string(buf), nil
+		}
+		if len(buf) >= maxNameLen {
+			return "", ErrNameTooLong
+		}
+		buf = append(buf, b)
+	}
+}
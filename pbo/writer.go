@@ -0,0 +1,426 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 Maxim Levchenko (WoozyMasta)
+// Source: github.com/woozymasta/lzss
+
+package pbo
+
+import (
+	"bytes"
+	"crypto/sha1" // #nosec G505 -- PBO trailer format uses SHA1
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/woozymasta/lzss"
+)
+
+// Writer writes a PBO archive.
+// The header precedes entry data and is known only after the last entry, so stored
+// entry data (compressed when the policy says so) is spooled to a temporary file.
+// Data written with Create goes straight to the spool and is compressed from there,
+// so entries are not held in memory. Close writes the header, spooled data and SHA1
+// trailer to the underlying writer and removes the temporary file,
+// so Close must be called even when writing fails.
+type Writer struct {
+	w io.Writer
+
+	// Policy selects compression per entry; nil stores all entries.
+	Policy Policy
+
+	// DecodeOptions is the dialect readers use for the archive (see Reader.DecodeOptions);
+	// nil means lzss.DefaultOptions. Policy options with another checksum mode or
+	// min match length are rejected with ErrDialectMismatch.
+	DecodeOptions *lzss.Options
+
+	pending    *writerEntry // entry opened by Create
+	pendingOff int64        // spool offset of the pending entry data
+	spool      *os.File
+	spoolSize  int64
+	names      map[string]struct{}
+
+	props   []Property
+	entries []*writerEntry
+
+	closed bool
+}
+
+// writerEntry is an entry header; its stored data is in the spool file.
+type writerEntry struct {
+	Entry
+}
+
+// NewWriter returns a Writer writing a PBO archive to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{
+		w:     w,
+		names: make(map[string]struct{}),
+	}
+}
+
+// SetProperty sets a product entry property (e.g. "prefix"). Properties are written in order of first set.
+func (w *Writer) SetProperty(key, value string) error {
+	if key == "" || strings.IndexByte(key, 0) >= 0 || strings.IndexByte(value, 0) >= 0 {
+		return fmt.Errorf("%w: property %q", ErrInvalidName, key)
+	}
+
+	for i := range w.props {
+		if w.props[i].Key == key {
+			w.props[i].Value = value
+			return nil
+		}
+	}
+	w.props = append(w.props, Property{Key: key, Value: value})
+
+	return nil
+}
+
+// Create adds an entry and returns a writer for its unpacked data.
+// The data is finalized on the next Create, WriteFile or Close call;
+// later writes fail with ErrEntryClosed. Forward slashes in name are stored as backslashes.
+func (w *Writer) Create(name string, modTime time.Time) (io.Writer, error) {
+	if err := w.flushPending(); err != nil {
+		return nil, err
+	}
+
+	entry, err := w.addEntry(name, modTime)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.openSpool(); err != nil {
+		return nil, err
+	}
+
+	w.pending = entry
+	w.pendingOff = w.spoolSize
+
+	return &entryWriter{w: w, e: entry}, nil
+}
+
+// WriteFile adds an entry with data.
+func (w *Writer) WriteFile(name string, data []byte, modTime time.Time) error {
+	if err := w.flushPending(); err != nil {
+		return err
+	}
+
+	entry, err := w.addEntry(name, modTime)
+	if err != nil {
+		return err
+	}
+
+	if err := w.finishEntry(entry, data); err != nil {
+		// Drop the entry so its header does not outlive the rejected data.
+		w.entries = w.entries[:len(w.entries)-1]
+		delete(w.names, strings.ToLower(entry.Name))
+
+		return err
+	}
+
+	return nil
+}
+
+// Close finalizes the last entry and writes the archive with SHA1 trailer.
+// It removes the spool file, also on error. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	if w.closed {
+		return ErrWriterClosed
+	}
+	defer w.removeSpool()
+
+	err := w.flushPending()
+	w.closed = true
+	if err != nil {
+		return err
+	}
+
+	h := sha1.New() // #nosec G401 -- PBO trailer format uses SHA1
+	mw := io.MultiWriter(w.w, h)
+
+	if _, err := mw.Write(w.header()); err != nil {
+		return err
+	}
+	if w.spool != nil {
+		if _, err := w.spool.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		if _, err := io.Copy(mw, w.spool); err != nil {
+			return err
+		}
+	}
+
+	trailer := append([]byte{0}, h.Sum(nil)...)
+	_, err = w.w.Write(trailer)
+
+	return err
+}
+
+// addEntry validates name and appends a new entry.
+func (w *Writer) addEntry(name string, modTime time.Time) (*writerEntry, error) {
+	if w.closed {
+		return nil, ErrWriterClosed
+	}
+
+	stored := strings.ReplaceAll(name, "/", "\\")
+	if stored == "" || strings.IndexByte(stored, 0) >= 0 || len(stored) > maxNameLen {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidName, name)
+	}
+
+	key := strings.ToLower(stored)
+	if _, ok := w.names[key]; ok {
+		return nil, fmt.Errorf("%w: %q", ErrDuplicateName, name)
+	}
+	w.names[key] = struct{}{}
+
+	var timestamp uint32
+	if unix := modTime.Unix(); !modTime.IsZero() && unix > 0 && unix <= math.MaxUint32 {
+		timestamp = uint32(unix) // #nosec G115 -- range checked above
+	}
+
+	entry := &writerEntry{Entry: Entry{Name: stored, Timestamp: timestamp}}
+	w.entries = append(w.entries, entry)
+
+	return entry, nil
+}
+
+// flushPending finalizes the entry opened by Create. Its raw data is at the spool end;
+// when the policy compresses it, the compressed data replaces the raw data.
+func (w *Writer) flushPending() error {
+	e := w.pending
+	if e == nil {
+		return nil
+	}
+	w.pending = nil
+
+	size := w.spoolSize - w.pendingOff
+	if size > math.MaxUint32 {
+		return fmt.Errorf("%w: %q", ErrEntryTooLarge, e.Name)
+	}
+
+	e.Method = MethodUncompressed
+	e.DataSize = uint32(size) // #nosec G115 -- range checked above
+
+	decision, err := w.decide(e.Name, size)
+	if err != nil || decision.Options == nil {
+		return err
+	}
+
+	end := w.spoolSize
+	raw := io.NewSectionReader(w.spool, w.pendingOff, size)
+	n, err := lzss.CompressToWriter(spoolWriter{w}, raw, decision.Options)
+	if err != nil {
+		return fmt.Errorf("%s: %w", e.Name, err)
+	}
+	if decision.OnlyIfSmaller && n >= size {
+		return w.truncateSpool(end)
+	}
+	if n > math.MaxUint32 {
+		return fmt.Errorf("%w: %q", ErrEntryTooLarge, e.Name)
+	}
+
+	// Move compressed data over the raw data; the copy reads ahead of its writes.
+	dst := io.NewOffsetWriter(w.spool, w.pendingOff)
+	if _, err := io.Copy(dst, io.NewSectionReader(w.spool, end, n)); err != nil {
+		return err
+	}
+
+	e.Method = MethodCompressed
+	e.OriginalSize = e.DataSize
+	e.DataSize = uint32(n) // #nosec G115 -- range checked above
+
+	return w.truncateSpool(w.pendingOff + n)
+}
+
+// finishEntry applies the policy to data and sets method, sizes and stored data.
+func (w *Writer) finishEntry(e *writerEntry, data []byte) error {
+	if uint64(len(data)) > math.MaxUint32 {
+		return fmt.Errorf("%w: %q", ErrEntryTooLarge, e.Name)
+	}
+
+	e.Method = MethodUncompressed
+	e.DataSize = uint32(len(data)) // #nosec G115 -- range checked above
+
+	decision, err := w.decide(e.Name, int64(len(data)))
+	if err != nil {
+		return err
+	}
+	if decision.Options == nil {
+		return w.spoolData(data)
+	}
+
+	var enc []byte
+	if decision.OnlyIfSmaller {
+		var ok bool
+		enc, ok, err = lzss.CompressIfSmaller(data, decision.Options, 1)
+		if err == nil && !ok {
+			return w.spoolData(data)
+		}
+	} else {
+		enc, err = lzss.Compress(data, decision.Options)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", e.Name, err)
+	}
+	if uint64(len(enc)) > math.MaxUint32 {
+		return fmt.Errorf("%w: %q", ErrEntryTooLarge, e.Name)
+	}
+
+	e.Method = MethodCompressed
+	e.OriginalSize = uint32(len(data)) // #nosec G115 -- range checked above
+	e.DataSize = uint32(len(enc))      // #nosec G115 -- range checked above
+
+	return w.spoolData(enc)
+}
+
+// decide returns the policy decision for an entry with unpacked size and checks its dialect.
+func (w *Writer) decide(name string, size int64) (Decision, error) {
+	if w.Policy == nil || size == 0 {
+		return Decision{}, nil
+	}
+
+	decision := w.Policy.Decide(name, int(size)) // #nosec G115 -- entry size fits uint32
+	if decision.Options == nil {
+		return decision, nil
+	}
+
+	dec := w.DecodeOptions
+	if dec == nil {
+		dec = lzss.DefaultOptions()
+	}
+	if decision.Options.Checksum != dec.Checksum ||
+		minMatch(decision.Options.MinMatchLength) != minMatch(dec.MinMatchLength) {
+		return Decision{}, fmt.Errorf("%w: %q", ErrDialectMismatch, name)
+	}
+
+	return decision, nil
+}
+
+// minMatch returns the effective min match length (zero is lzss.MinMatchDefault).
+func minMatch(n int) int {
+	if n == 0 {
+		return lzss.MinMatchDefault
+	}
+
+	return n
+}
+
+// spoolData appends stored entry data to the spool file, creating it on first use.
+func (w *Writer) spoolData(data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if err := w.openSpool(); err != nil {
+		return err
+	}
+
+	_, err := spoolWriter{w}.Write(data)
+
+	return err
+}
+
+// openSpool creates the spool file on first use.
+func (w *Writer) openSpool() error {
+	if w.spool != nil {
+		return nil
+	}
+
+	f, err := os.CreateTemp("", "pbo-*")
+	if err != nil {
+		return err
+	}
+	w.spool = f
+
+	return nil
+}
+
+// truncateSpool cuts the spool file to size and continues writing there.
+func (w *Writer) truncateSpool(size int64) error {
+	if err := w.spool.Truncate(size); err != nil {
+		return err
+	}
+	if _, err := w.spool.Seek(size, io.SeekStart); err != nil {
+		return err
+	}
+	w.spoolSize = size
+
+	return nil
+}
+
+// removeSpool closes and deletes the spool file.
+func (w *Writer) removeSpool() {
+	if w.spool == nil {
+		return
+	}
+
+	name := w.spool.Name()
+	_ = w.spool.Close()
+	_ = os.Remove(name)
+	w.spool = nil
+}
+
+// spoolWriter appends to the spool file and tracks its size.
+type spoolWriter struct {
+	w *Writer
+}
+
+// Write appends p to the spool.
+func (s spoolWriter) Write(p []byte) (int, error) {
+	n, err := s.w.spool.Write(p)
+	s.w.spoolSize += int64(n)
+
+	return n, err
+}
+
+// entryWriter writes data of an entry opened by Create to the spool.
+type entryWriter struct {
+	w *Writer
+	e *writerEntry
+}
+
+// Write appends p to the entry data.
+func (ew *entryWriter) Write(p []byte) (int, error) {
+	if ew.w.pending != ew.e {
+		return 0, fmt.Errorf("%w: %q", ErrEntryClosed, ew.e.Name)
+	}
+
+	return spoolWriter{ew.w}.Write(p)
+}
+
+// header serializes product entry, entry headers and terminator.
+func (w *Writer) header() []byte {
+	var buf bytes.Buffer
+
+	if len(w.props) > 0 {
+		writeHeaderEntry(&buf, &Entry{Method: MethodVersion})
+		for _, p := range w.props {
+			buf.WriteString(p.Key)
+			buf.WriteByte(0)
+			buf.WriteString(p.Value)
+			buf.WriteByte(0)
+		}
+		buf.WriteByte(0)
+	}
+
+	for _, e := range w.entries {
+		writeHeaderEntry(&buf, &e.Entry)
+	}
+	writeHeaderEntry(&buf, &Entry{})
+
+	return buf.Bytes()
+}
+
+// writeHeaderEntry writes ASCIIZ name and 5 uint32 fields.
+func writeHeaderEntry(buf *bytes.Buffer, e *Entry) {
+	buf.WriteString(e.Name)
+	buf.WriteByte(0)
+
+	var fields [headerFieldsSize]byte
+	binary.LittleEndian.PutUint32(fields[0:4], e.Method)
+	binary.LittleEndian.PutUint32(fields[4:8], e.OriginalSize)
+	binary.LittleEndian.PutUint32(fields[8:12], e.Reserved)
+	binary.LittleEndian.PutUint32(fields[12:16], e.Timestamp)
+	binary.LittleEndian.PutUint32(fields[16:20], e.DataSize)
+	buf.Write(fields[:])
+}
@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 Maxim Levchenko (WoozyMasta)
+// Source: github.com/woozymasta/lzss
+
+package lzss
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Dialect is a named pair of decode and encode options used by one file format.
+type Dialect struct {
+	Name        string          // Preset name, matched case-insensitively.
+	Description string          // Formats using the dialect.
+	Options     Options         // Decode options.
+	Compress    CompressOptions // Encode options producing blocks the format accepts.
+}
+
+// builtinPresets are the dialects known to the package.
+var builtinPresets = []Dialect{
+	{
+		Name:        "pbo",
+		Description: "Arma/DayZ PBO entries (Cprs): unsigned checksum, verified, min match 3",
+		Options:     Options{Checksum: ChecksumUnsigned, VerifyChecksum: true, MinMatchLength: MinMatchDefault},
+		Compress:    CompressOptions{Checksum: ChecksumUnsigned, SearchLimit: 2048, MinMatchLength: MinMatchDefault},
+	},
+	{
+		Name:        "paa",
+		Description: "PAA/PAC non-DXT texture mipmaps: signed checksum, not verified, min match 3",
+		Options:     Options{Checksum: ChecksumSigned, MinMatchLength: MinMatchDefault},
+		Compress:    CompressOptions{Checksum: ChecksumSigned, SearchLimit: 2048, MinMatchLength: MinMatchDefault},
+	},
+	{
+		Name:        "mm2",
+		Description: "min match 2 dialect (length nibble + 2): unsigned checksum, verified",
+		Options:     Options{Checksum: ChecksumUnsigned, VerifyChecksum: true, MinMatchLength: MinMatch2},
+		Compress:    CompressOptions{Checksum: ChecksumUnsigned, SearchLimit: 2048, MinMatchLength: MinMatch2},
+	},
+}
+
+// presets is the preset registry keyed by lower-case name.
+var presets = struct {
+	m map[string]Dialect
+	sync.RWMutex
+}{m: presetMap(builtinPresets)}
+
+// Preset returns copies of the decode and encode options of the named preset.
+func Preset(name string) (*Options, *CompressOptions, error) {
+	d, ok := LookupPreset(name)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %q", ErrUnknownPreset, name)
+	}
+
+	return &d.Options, &d.Compress, nil
+}
+
+// LookupPreset returns the named preset.
+func LookupPreset(name string) (Dialect, bool) {
+	presets.RLock()
+	defer presets.RUnlock()
+
+	d, ok := presets.m[strings.ToLower(name)]
+
+	return d, ok
+}
+
+// RegisterPreset adds a user preset. Names are case-insensitive and cannot be replaced;
+// zero MinMatchLength values are stored as MinMatchDefault.
+func RegisterPreset(d Dialect) error {
+	if d.Name == "" {
+		return fmt.Errorf("%w: empty name", ErrInvalidPreset)
+	}
+
+	d.Options.MinMatchLength = normalizeMinMatch(d.Options.MinMatchLength)
+	d.Compress.MinMatchLength = normalizeMinMatch(d.Compress.MinMatchLength)
+	for _, minMatch := range []int{d.Options.MinMatchLength, d.Compress.MinMatchLength} {
+		if minMatch != MinMatchDefault && minMatch != MinMatch2 {
+			return fmt.Errorf("%w: %q min match %d", ErrInvalidPreset, d.Name, minMatch)
+		}
+	}
+
+	key := strings.ToLower(d.Name)
+
+	presets.Lock()
+	defer presets.Unlock()
+
+	if _, ok := presets.m[key]; ok {
+		return fmt.Errorf("%w: %q", ErrPresetExists, d.Name)
+	}
+	presets.m[key] = d
+
+	return nil
+}
+
+// Presets returns all registered presets sorted by name.
+func Presets() []Dialect {
+	presets.RLock()
+	defer presets.RUnlock()
+
+	list := make([]Dialect, 0, len(presets.m))
+	for _, d := range presets.m {
+		list = append(list, d)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+
+	return list
+}
+
+// PresetNames returns names of presets whose block format (checksum mode and min match)
+// equals opts, sorted; e.g. to report detected or stored options (FrameHeader.Options) by name.
+func PresetNames(opts *Options) []string {
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+	minMatch := normalizeMinMatch(opts.MinMatchLength)
+
+	var names []string
+	for _, d := range Presets() {
+		if d.Options.Checksum == opts.Checksum && d.Options.MinMatchLength == minMatch {
+			names = append(names, d.Name)
+		}
+	}
+
+	return names
+}
+
+// presetMap indexes dialects by lower-case name.
+func presetMap(list []Dialect) map[string]Dialect {
+	m := make(map[string]Dialect, len(list))
+	for _, d := range list {
+		m[strings.ToLower(d.Name)] = d
+	}
+
+	return m
+}
+
+// normalizeMinMatch maps zero to MinMatchDefault.
+func normalizeMinMatch(minMatch int) int {
+	if minMatch == 0 {
+		return MinMatchDefault
+	}
+
+	return minMatch
+}
@@ -0,0 +1,85 @@
+package lzss
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestPresets(t *testing.T) {
+	for _, name := range []string{"pbo", "PAA", "mm2"} {
+		opts, copts, err := Preset(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		raw := bytes.Repeat([]byte("preset round trip "), 50)
+		enc, err := Compress(raw, copts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dec, err := Decompress(enc, len(raw), opts)
+		if err != nil || !bytes.Equal(dec, raw) {
+			t.Fatalf("%s: round-trip err=%v", name, err)
+		}
+
+		// Returned options are copies.
+		opts.Strict = true
+		if again, _, _ := Preset(name); again.Strict {
+			t.Fatalf("%s: preset modified through returned options", name)
+		}
+	}
+
+	if _, _, err := Preset("nope"); !errors.Is(err, ErrUnknownPreset) {
+		t.Fatalf("unknown: %v", err)
+	}
+
+	if got := PresetNames(SignedLenientOptions()); !slices.Equal(got, []string{"paa"}) {
+		t.Fatalf("signed names=%v", got)
+	}
+	if got := PresetNames(&Options{MinMatchLength: MinMatch2}); !slices.Equal(got, []string{"mm2"}) {
+		t.Fatalf("mm2 names=%v", got)
+	}
+}
+
+func TestRegisterPreset(t *testing.T) {
+	d := Dialect{
+		Name:     "test-signed-mm2",
+		Options:  Options{Checksum: ChecksumSigned, MinMatchLength: MinMatch2},
+		Compress: CompressOptions{Checksum: ChecksumSigned, SearchLimit: 512, MinMatchLength: MinMatch2},
+	}
+	// The registry is global; with -count > 1 the preset is already there.
+	if _, ok := LookupPreset(d.Name); !ok {
+		if err := RegisterPreset(d); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := RegisterPreset(Dialect{Name: "TEST-SIGNED-MM2"}); !errors.Is(err, ErrPresetExists) {
+		t.Fatalf("duplicate: %v", err)
+	}
+	if err := RegisterPreset(Dialect{Name: "pbo"}); !errors.Is(err, ErrPresetExists) {
+		t.Fatalf("builtin override: %v", err)
+	}
+	if err := RegisterPreset(Dialect{}); !errors.Is(err, ErrInvalidPreset) {
+		t.Fatalf("empty name: %v", err)
+	}
+	if err := RegisterPreset(Dialect{Name: "bad", Options: Options{MinMatchLength: 5}}); !errors.Is(err, ErrInvalidPreset) {
+		t.Fatalf("bad min match: %v", err)
+	}
+
+	if _, copts, err := Preset("test-signed-mm2"); err != nil || copts.SearchLimit != 512 {
+		t.Fatalf("lookup: %+v %v", copts, err)
+	}
+	if got := PresetNames(&d.Options); !slices.Contains(got, "test-signed-mm2") {
+		t.Fatalf("names=%v", got)
+	}
+
+	found := false
+	for _, p := range Presets() {
+		found = found || p.Name == d.Name
+	}
+	if !found {
+		t.Fatal("registered preset not listed")
+	}
+}
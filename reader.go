@@ -4,7 +4,10 @@
 
 package lzss
 
-import "io"
+import (
+	"bufio"
+	"io"
+)
 
 // sliceByteReader reads from a byte slice.
 type sliceByteReader struct {
@@ -14,8 +17,16 @@ type sliceByteReader struct {
 
 // countingByteReader reads from a byte reader and counts the number of bytes read.
 type countingByteReader struct {
-	base  io.ByteReader // The byte reader to read from.
-	count int64         // The number of bytes read.
+	base   io.ByteReader // The byte reader to read from.
+	count  int64         // The number of bytes read.
+	seeker io.ReadSeeker // Underlying reader when base is a read-ahead buffer over it.
+	buf    *bufio.Reader // Read-ahead buffer returned to seeker on release.
+}
+
+// unbufferedByteReader reads one byte per Read call, so nothing is read past the last returned byte.
+type unbufferedByteReader struct {
+	r   io.Reader // The reader to read from.
+	one [1]byte   // Read buffer.
 }
 
 // ReadByte reads a byte from the slice.
@@ -41,3 +52,25 @@ func (r *countingByteReader) ReadByte() (byte, error) {
 
 	return b, nil
 }
+
+// release seeks the underlying reader back over bytes buffered but not consumed,
+// leaving it positioned right after the last byte returned by ReadByte.
+func (r *countingByteReader) release() error {
+	if r.buf == nil || r.buf.Buffered() == 0 {
+		return nil
+	}
+
+	_, err := r.seeker.Seek(-int64(r.buf.Buffered()), io.SeekCurrent)
+	r.buf.Reset(r.seeker)
+
+	return err
+}
+
+// ReadByte reads exactly one byte from the reader.
+func (r *unbufferedByteReader) ReadByte() (byte, error) {
+	if _, err := io.ReadFull(r.r, r.one[:]); err != nil {
+		return 0, err
+	}
+
+	return r.one[0], nil
+}
@@ -0,0 +1,138 @@
+package lzss
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// exactSources returns readers over data for each kind of source with exact-consumption semantics.
+func exactSources(t *testing.T, data []byte) map[string]func() io.Reader {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "stream.bin")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return map[string]func() io.Reader{
+		"bytes": func() io.Reader { return bytes.NewReader(data) },
+		"plain": func() io.Reader { return onlyReader{bytes.NewReader(data)} },
+		"file": func() io.Reader {
+			f, err := os.Open(path) // #nosec G304 -- test temp file
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = f.Close() })
+
+			return f
+		},
+		"pipe": func() io.Reader {
+			pr, pw, err := os.Pipe()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = pr.Close() })
+			go func() {
+				_, _ = pw.Write(data)
+				_ = pw.Close()
+			}()
+
+			return pr
+		},
+	}
+}
+
+func TestExactConsumption(t *testing.T) {
+	raw := bytes.Repeat([]byte("exact consumption, no read-ahead "), 300)
+	enc, err := Compress(raw, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tail := []byte("NEXT CONTAINER FIELD")
+	data := append(append([]byte{}, enc...), tail...)
+
+	for name, open := range exactSources(t, data) {
+		decoders := map[string]func(io.Reader) ([]byte, error){
+			"DecompressFromReader": func(r io.Reader) ([]byte, error) {
+				out, _, err := DecompressFromReader(r, len(raw), nil)
+
+				return out, err
+			},
+			"DecompressNFromReader": func(r io.Reader) ([]byte, error) {
+				blocks, _, err := DecompressNFromReader(r, []int{len(raw)}, nil)
+				if err != nil {
+					return nil, err
+				}
+
+				return blocks[0], nil
+			},
+			"DecompressUntilEOF": func(r io.Reader) ([]byte, error) {
+				done := false
+				blocks, _, err := DecompressUntilEOF(r, func() (int, bool) {
+					if done {
+						return 0, false
+					}
+					done = true
+
+					return len(raw), true
+				}, nil)
+				if err != nil {
+					return nil, err
+				}
+
+				return blocks[0], nil
+			},
+			"DecompressNSeq": func(r io.Reader) ([]byte, error) {
+				for block, err := range DecompressNSeq(r, []int{len(raw)}, nil) {
+					return block.Data, err
+				}
+
+				return nil, io.ErrUnexpectedEOF
+			},
+			"BlockSizeFromReader": func(r io.Reader) ([]byte, error) {
+				if _, err := BlockSizeFromReader(r, len(raw), nil); err != nil {
+					return nil, err
+				}
+
+				return raw, nil
+			},
+			"NewReader": func(r io.Reader) ([]byte, error) {
+				zr, err := NewReader(r, len(raw), nil)
+				if err != nil {
+					return nil, err
+				}
+
+				return io.ReadAll(zr)
+			},
+		}
+
+		for decoder, decode := range decoders {
+			src := open()
+			out, err := decode(src)
+			if err != nil {
+				t.Fatalf("%s/%s: %v", name, decoder, err)
+			}
+			if !bytes.Equal(out, raw) {
+				t.Fatalf("%s/%s: output mismatch", name, decoder)
+			}
+
+			if f, ok := src.(*os.File); ok && name == "file" {
+				pos, err := f.Seek(0, io.SeekCurrent)
+				if err != nil || pos != int64(len(enc)) {
+					t.Fatalf("%s/%s: position=%d err=%v", name, decoder, pos, err)
+				}
+			}
+
+			rest, err := io.ReadAll(src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(rest, tail) {
+				t.Fatalf("%s/%s: rest=%q", name, decoder, rest)
+			}
+		}
+	}
+}
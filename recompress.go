@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 Maxim Levchenko (WoozyMasta)
+// Source: github.com/woozymasta/lzss
+
+package lzss
+
+import (
+	"fmt"
+	"io"
+)
+
+// Recompress decodes the block src with in and encodes the output again with out,
+// e.g. to convert a signed checksum block to unsigned or to re-encode a poorly compressed
+// entry with a larger SearchLimit. src must hold exactly one block, as for Decompress;
+// lenient in options also repair a bad stored checksum. The result may be larger than src,
+// compare sizes to keep the original. Options nil mean DefaultOptions() and DefaultCompressOptions().
+// Empty blocks are rejected with ErrEmptyInput.
+func Recompress(src []byte, outLen int, in *Options, out *CompressOptions) ([]byte, error) {
+	dec, err := Decompress(src, outLen, in)
+	if err != nil {
+		return nil, err
+	}
+
+	return Compress(dec, out)
+}
+
+// RecompressNToWriter is the streaming form of Recompress: it decodes len(outLens) back-to-back
+// blocks from r one at a time and writes each re-encoded block to w.
+// It returns compressed sizes of the written blocks and input bytes consumed,
+// leaving r positioned after the last block as DecompressNFromReader does.
+func RecompressNToWriter(w io.Writer, r io.Reader, outLens []int, in *Options, out *CompressOptions) ([]int, int64, error) {
+	sizes := make([]int, 0, len(outLens))
+	var consumed int64
+	for block, err := range DecompressNSeq(r, outLens, in) {
+		if err != nil {
+			return sizes, consumed, err
+		}
+		consumed += block.Consumed
+
+		enc, err := Compress(block.Data, out)
+		if err != nil {
+			return sizes, consumed, fmt.Errorf("block %d: %w", block.Index, err)
+		}
+		if _, err := w.Write(enc); err != nil {
+			return sizes, consumed, err
+		}
+
+		sizes = append(sizes, len(enc))
+	}
+
+	return sizes, consumed, nil
+}
@@ -0,0 +1,97 @@
+package lzss
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+func TestRecompress(t *testing.T) {
+	raw := append(bytes.Repeat([]byte("recompress me \xf0\xf1 "), 60), 0xFF)
+
+	// Signed block with a wrong checksum, stored literals-only.
+	signed, err := Compress(raw, &CompressOptions{Checksum: ChecksumSigned})
+	if err != nil {
+		t.Fatal(err)
+	}
+	binary.LittleEndian.PutUint32(signed[len(signed)-4:], 0xDEADBEEF)
+
+	if _, err := Recompress(signed, len(raw), &Options{Checksum: ChecksumSigned, VerifyChecksum: true}, nil); !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("strict input err=%v", err)
+	}
+
+	enc, err := Recompress(signed, len(raw), SignedLenientOptions(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(enc) >= len(signed) {
+		t.Fatalf("size %d, literals-only %d", len(enc), len(signed))
+	}
+	dec, err := Decompress(enc, len(raw), nil)
+	if err != nil || !bytes.Equal(dec, raw) {
+		t.Fatalf("unsigned decode err=%v", err)
+	}
+
+	// Min match 3 to min match 2.
+	enc, err = Recompress(enc, len(raw), nil, &CompressOptions{SearchLimit: 4095, MinMatchLength: MinMatch2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dec, err := Decompress(enc, len(raw), &Options{VerifyChecksum: true, MinMatchLength: MinMatch2}); err != nil || !bytes.Equal(dec, raw) {
+		t.Fatalf("mm2 decode err=%v", err)
+	}
+
+	if _, err := Recompress(append(enc, 0), len(raw), &Options{MinMatchLength: MinMatch2}, nil); !errors.Is(err, ErrTrailingData) {
+		t.Fatalf("trailing data err=%v", err)
+	}
+}
+
+func TestRecompressNToWriter(t *testing.T) {
+	raws := [][]byte{
+		bytes.Repeat([]byte("stream block one "), 30),
+		bytes.Repeat([]byte("two \x90 "), 50),
+	}
+	in := &CompressOptions{Checksum: ChecksumSigned}
+	var src bytes.Buffer
+	packed, err := CompressNToWriter(&src, raws, in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src.WriteString("tail")
+
+	outLens := []int{len(raws[0]), len(raws[1])}
+	var dst bytes.Buffer
+	sizes, consumed, err := RecompressNToWriter(&dst, &src, outLens, &Options{Checksum: ChecksumSigned, VerifyChecksum: true}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if consumed != int64(packed[0]+packed[1]) || src.String() != "tail" {
+		t.Fatalf("consumed=%d rest=%q", consumed, src.String())
+	}
+	if len(sizes) != 2 || sizes[0]+sizes[1] != dst.Len() {
+		t.Fatalf("sizes=%v written=%d", sizes, dst.Len())
+	}
+
+	got, _, err := DecompressNFromReader(&dst, outLens, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range raws {
+		if !bytes.Equal(got[i], raws[i]) {
+			t.Fatalf("block %d mismatch", i)
+		}
+	}
+
+	// Decode errors report the failing block; earlier blocks are already written.
+	var short bytes.Buffer
+	if _, err := CompressNToWriter(&short, raws, nil); err != nil {
+		t.Fatal(err)
+	}
+	dst.Reset()
+	sizes, _, err = RecompressNToWriter(&dst, bytes.NewReader(short.Bytes()[:short.Len()-1]), outLens, nil, nil)
+	var decErr *DecodeError
+	if !errors.As(err, &decErr) || decErr.Block != 1 || len(sizes) != 1 || dst.Len() != sizes[0] {
+		t.Fatalf("sizes=%v err=%v", sizes, err)
+	}
+}
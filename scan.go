@@ -0,0 +1,304 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 Maxim Levchenko (WoozyMasta)
+// Source: github.com/woozymasta/lzss
+
+package lzss
+
+import (
+	"encoding/binary"
+	"slices"
+)
+
+// ScanMode selects how Scan finds the decoded size of candidate blocks.
+type ScanMode int
+
+// Scan mode constants.
+const (
+	// ScanSizeFields takes outLen candidates from 32-bit little-endian values
+	// starting in the SizeFieldRange bytes before each offset (nearest first).
+	ScanSizeFields ScanMode = iota
+
+	// ScanBruteForce decodes without a known size and accepts the first token boundary
+	// where the next 4 bytes equal the running checksum. Data that decodes without
+	// errors (long 0xFF runs, lenient dialects) costs up to MaxOutLen per offset.
+	ScanBruteForce
+)
+
+// Scan defaults.
+const (
+	scanMinOutLen      = 16
+	scanMaxOutLen      = 1 << 20
+	scanSizeFieldRange = 16
+)
+
+// ScanOptions configures Scan. Zero values select defaults.
+type ScanOptions struct {
+	// Options are the dialects tried at each offset, in order. Nil means one strict dialect
+	// per distinct checksum mode and min match of registered presets.
+	// Strict dialects reject most offsets after a few tokens, which keeps scanning fast;
+	// pass Strict false to also find blocks that rely on filler pre-fill. Lenient dialects
+	// decode until end of data or MaxOutLen at most offsets, so scanning gets much slower.
+	// VerifyChecksum is always on: a block is reported only when its checksum validates.
+	Options []Options
+	// Mode selects size fields (default) or checksum-only brute force.
+	Mode ScanMode
+	// MinOutLen is the smallest decoded size reported (default 16); tiny blocks validate by chance.
+	MinOutLen int
+	// MaxOutLen is the largest decoded size tried (default 1 MiB).
+	MaxOutLen int
+	// SizeFieldRange is the number of bytes before an offset searched for size fields (default 16).
+	SizeFieldRange int
+	// Overlapping also reports blocks starting inside a found block;
+	// by default scanning resumes after the end of each found block.
+	Overlapping bool
+}
+
+// ScanResult is an LZSS block found by Scan.
+type ScanResult struct {
+	Presets   []string // Names of registered presets matching any dialect that validates the block.
+	Options   Options  // First dialect that validates the block.
+	Offset    int      // Block start in data.
+	OutLen    int      // Decoded size.
+	Consumed  int      // Compressed size including the 4-byte checksum.
+	SizeField int      // Offset of the size field in data (ScanSizeFields); -1 for ScanBruteForce.
+}
+
+// Scan searches data for embedded LZSS:8bit blocks by trying to decode at every offset,
+// e.g. to carve compressed payloads out of unknown file formats.
+// Results are in offset order and always have a validating checksum.
+func Scan(data []byte, opts ScanOptions) []ScanResult {
+	s := &scanner{data: data, opts: opts}
+	if s.opts.Options == nil {
+		s.opts.Options = scanDialects()
+	}
+	if s.opts.MinOutLen <= 0 {
+		s.opts.MinOutLen = scanMinOutLen
+	}
+	if s.opts.MaxOutLen <= 0 {
+		s.opts.MaxOutLen = scanMaxOutLen
+	}
+	if s.opts.SizeFieldRange <= 0 {
+		s.opts.SizeFieldRange = scanSizeFieldRange
+	}
+
+	var results []ScanResult
+	// The smallest block is one flag byte, one literal and the checksum.
+	for off := 0; off+6 <= len(data); {
+		res, ok := s.at(off)
+		if !ok {
+			off++
+
+			continue
+		}
+
+		results = append(results, res)
+		if opts.Overlapping {
+			off++
+		} else {
+			off += res.Consumed
+		}
+	}
+
+	return results
+}
+
+// scanDialects returns strict options for each distinct block format of registered presets.
+func scanDialects() []Options {
+	var list []Options
+	for _, d := range Presets() {
+		opts := Options{
+			Checksum:       d.Options.Checksum,
+			VerifyChecksum: true,
+			MinMatchLength: normalizeMinMatch(d.Options.MinMatchLength),
+			Strict:         true,
+		}
+		if !slices.Contains(list, opts) {
+			list = append(list, opts)
+		}
+	}
+
+	return list
+}
+
+// scanner holds Scan state; buf is reused by decode, fields by sizeFields.
+type scanner struct {
+	data   []byte
+	buf    []byte
+	fields []scanField
+	opts   ScanOptions
+}
+
+// scanField is an outLen candidate read from a size field.
+type scanField struct {
+	outLen int
+	offset int
+}
+
+// at tries all dialects at offset off and reports the first validating block.
+func (s *scanner) at(off int) (ScanResult, bool) {
+	src := s.data[off:]
+	if s.opts.Mode == ScanSizeFields {
+		s.sizeFields(off)
+		if len(s.fields) == 0 {
+			return ScanResult{}, false
+		}
+	}
+
+	var (
+		res   ScanResult
+		found bool
+	)
+	for i := range s.opts.Options {
+		opts := s.opts.Options[i]
+		opts.VerifyChecksum = true
+
+		if found {
+			// Other dialects only add preset names for the same block.
+			if _, info, err := decompressSlice(src, res.OutLen, &opts); err == nil && int(info.Consumed) == res.Consumed {
+				res.Presets = append(res.Presets, PresetNames(&opts)...)
+			}
+
+			continue
+		}
+
+		var outLen, consumed int
+		field := -1
+		if s.opts.Mode == ScanBruteForce {
+			outLen, consumed, found = s.bruteForce(src, &opts)
+		} else {
+			outLen, consumed, field, found = s.fieldsDecode(src, &opts)
+		}
+		if found {
+			res = ScanResult{
+				Presets:   PresetNames(&opts),
+				Options:   opts,
+				Offset:    off,
+				OutLen:    outLen,
+				Consumed:  consumed,
+				SizeField: field,
+			}
+		}
+	}
+
+	if found {
+		slices.Sort(res.Presets)
+		res.Presets = slices.Compact(res.Presets)
+	}
+
+	return res, found
+}
+
+// sizeFields collects outLen candidates from 32-bit fields before off, nearest first.
+func (s *scanner) sizeFields(off int) {
+	s.fields = s.fields[:0]
+	for k := 4; k <= s.opts.SizeFieldRange && k <= off; k++ {
+		v := binary.LittleEndian.Uint32(s.data[off-k:])
+		if v < uint32(s.opts.MinOutLen) || v > uint32(s.opts.MaxOutLen) { // #nosec G115 -- positive bounds
+			continue
+		}
+		s.fields = append(s.fields, scanField{outLen: int(v), offset: off - k})
+	}
+}
+
+// fieldsDecode decodes src once up to the largest size field candidate
+// and returns the first candidate whose block validates.
+func (s *scanner) fieldsDecode(src []byte, opts *Options) (outLen, consumed, field int, ok bool) {
+	limit := int(MaxDecodedSize(int64(len(src)), opts))
+	maxLen := 0
+	for _, f := range s.fields {
+		if f.outLen <= limit {
+			maxLen = max(maxLen, f.outLen)
+		}
+	}
+	if maxLen == 0 {
+		return 0, 0, -1, false
+	}
+
+	field = -1
+	outLen, consumed, ok = s.decode(src, opts, maxLen, func(prev, pos, _ int, _ int32) int {
+		for _, f := range s.fields {
+			if prev < f.outLen && f.outLen <= pos {
+				field = f.offset
+
+				return f.outLen
+			}
+		}
+
+		return 0
+	})
+	if !ok {
+		return 0, 0, -1, false
+	}
+
+	return outLen, consumed, field, true
+}
+
+// bruteForce decodes src without a known size and returns the first token boundary
+// where the next 4 bytes equal the running checksum and the block validates.
+func (s *scanner) bruteForce(src []byte, opts *Options) (outLen, consumed int, ok bool) {
+	return s.decode(src, opts, s.opts.MaxOutLen, func(_, pos, in int, crc int32) int {
+		if pos >= s.opts.MinOutLen && pos <= s.opts.MaxOutLen && in+4 <= len(src) &&
+			binary.LittleEndian.Uint32(src[in:]) == uint32(crc) { // #nosec G115 -- checksum bit pattern
+			return pos
+		}
+
+		return 0
+	})
+}
+
+// decode walks the tokens of src until limit bytes are produced or decoding fails.
+// After each token, end gets the output size before and after it, the input offset and
+// the running checksum, and returns a candidate outLen ending within the token or 0;
+// candidates are confirmed with a full decode. Strict options stop at the first invalid
+// token (zero offset, filler reference, overrun of limit), which rejects most offsets
+// after a few tokens.
+func (s *scanner) decode(src []byte, opts *Options, limit int, end func(prev, pos, in int, crc int32) int) (outLen, consumed int, ok bool) {
+	signed := opts.Checksum == ChecksumSigned
+
+	out := s.buf[:0]
+	defer func() { s.buf = out[:0] }()
+
+	var crc int32
+	add := func(b byte) {
+		out = append(out, b)
+		if signed {
+			crc += int32(int8(b)) // #nosec G115 -- signed byte value
+		} else {
+			crc += int32(b)
+		}
+	}
+
+	r := &sliceByteReader{data: src}
+	w := newTokenWalker(r, opts)
+	for {
+		t, more, err := w.next(limit)
+		if err != nil || !more {
+			return 0, 0, false
+		}
+
+		prev := len(out)
+		if t.literal {
+			add(t.b)
+		} else {
+			rpos := len(out) - t.offset
+			for range t.length {
+				switch {
+				case rpos < 0:
+					add(Filler)
+				case rpos < len(out):
+					add(out[rpos])
+				default:
+					// Zero offset refers to output not yet written.
+					add(0)
+				}
+				rpos++
+			}
+		}
+
+		if n := end(prev, len(out), r.pos, crc); n > 0 {
+			if _, info, err := decompressSlice(src, n, opts); err == nil {
+				return n, int(info.Consumed), true
+			}
+		}
+	}
+}
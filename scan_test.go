@@ -0,0 +1,165 @@
+package lzss
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// scanBlock is a block embedded into a scan test container.
+type scanBlock struct {
+	opts   *CompressOptions
+	raw    []byte
+	offset int
+	packed int
+}
+
+// scanContainer embeds blocks into random noise, each preceded by a 32-bit size field and padding.
+func scanContainer(t *testing.T, blocks []scanBlock) []byte {
+	t.Helper()
+
+	rng := rand.New(rand.NewPCG(5, 6))
+	noise := func(n int) []byte {
+		b := make([]byte, n)
+		for i := range b {
+			b[i] = byte(rng.UintN(256))
+		}
+
+		return b
+	}
+
+	data := noise(300)
+	for i := range blocks {
+		enc, err := Compress(blocks[i].raw, blocks[i].opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data = binary.LittleEndian.AppendUint32(data, uint32(len(blocks[i].raw))) // #nosec G115 -- test sizes
+		data = append(data, noise(i*3)...)
+		blocks[i].offset = len(data)
+		blocks[i].packed = len(enc)
+		data = append(data, enc...)
+		data = append(data, noise(200)...)
+	}
+
+	return data
+}
+
+// scanPayload returns compressible data with bytes above 0x7F, so signed and unsigned checksums differ.
+func scanPayload(text string, n int) []byte {
+	var b []byte
+	for i := 0; len(b) < n; i++ {
+		b = append(b, text...)
+		b = append(b, byte(0x80+i%64))
+	}
+
+	return b[:n]
+}
+
+func TestScan(t *testing.T) {
+	blocks := []scanBlock{
+		{raw: scanPayload("unsigned pbo block ", 900), opts: DefaultCompressOptions()},
+		{raw: scanPayload("signed paa mipmap ", 700), opts: &CompressOptions{Checksum: ChecksumSigned, SearchLimit: 2048}},
+		{raw: scanPayload("mm2 dialect block ", 500), opts: &CompressOptions{SearchLimit: 2048, MinMatchLength: MinMatch2}},
+	}
+	data := scanContainer(t, blocks)
+	wantPresets := [][]string{{"pbo"}, {"paa"}, {"mm2"}}
+
+	for _, mode := range []ScanMode{ScanSizeFields, ScanBruteForce} {
+		results := Scan(data, ScanOptions{Mode: mode})
+		if len(results) != len(blocks) {
+			t.Fatalf("mode %d: got %d results: %+v", mode, len(results), results)
+		}
+
+		for i, res := range results {
+			b := blocks[i]
+			if res.Offset != b.offset || res.OutLen != len(b.raw) || res.Consumed != b.packed {
+				t.Fatalf("mode %d block %d: got %+v, want offset=%d outLen=%d consumed=%d",
+					mode, i, res, b.offset, len(b.raw), b.packed)
+			}
+			if !slices.Equal(res.Presets, wantPresets[i]) {
+				t.Fatalf("mode %d block %d: presets %v, want %v", mode, i, res.Presets, wantPresets[i])
+			}
+
+			wantField := -1
+			if mode == ScanSizeFields {
+				wantField = b.offset - 4 - i*3
+			}
+			if res.SizeField != wantField {
+				t.Fatalf("mode %d block %d: size field %d, want %d", mode, i, res.SizeField, wantField)
+			}
+
+			dec, err := Decompress(data[res.Offset:res.Offset+res.Consumed], res.OutLen, &res.Options)
+			if err != nil || !bytes.Equal(dec, b.raw) {
+				t.Fatalf("mode %d block %d: decode err=%v", mode, i, err)
+			}
+		}
+	}
+}
+
+func TestScanOptions(t *testing.T) {
+	// ASCII output has equal signed and unsigned checksums: both presets match.
+	raw := bytes.Repeat([]byte("plain ascii text block "), 40)
+	blocks := []scanBlock{{raw: raw, opts: DefaultCompressOptions()}}
+	data := scanContainer(t, blocks)
+
+	results := Scan(data, ScanOptions{})
+	if len(results) != 1 || !slices.Equal(results[0].Presets, []string{"paa", "pbo"}) {
+		t.Fatalf("results %+v", results)
+	}
+
+	// Size field outside the searched range or decoded size above the limit.
+	if results := Scan(data, ScanOptions{SizeFieldRange: 3}); len(results) != 0 {
+		t.Fatalf("range 3: %+v", results)
+	}
+	if results := Scan(data, ScanOptions{Mode: ScanBruteForce, MaxOutLen: len(raw) - 1}); len(results) != 0 {
+		t.Fatalf("max out len: %+v", results)
+	}
+
+	// Explicit dialects: min match 2 cannot decode the block.
+	if results := Scan(data, ScanOptions{Options: []Options{{MinMatchLength: MinMatch2, Strict: true}}}); len(results) != 0 {
+		t.Fatalf("mm2 dialect: %+v", results)
+	}
+
+	if results := Scan(nil, ScanOptions{}); len(results) != 0 {
+		t.Fatalf("empty input: %+v", results)
+	}
+}
+
+func TestScanFiller(t *testing.T) {
+	// Leading spaces encoded as a back-reference into the filler region.
+	raw := append(bytes.Repeat([]byte{' '}, 18), scanPayload("filler ", 200)...)
+	// First group: pointer (offset 18, length 18) and seven literals.
+	enc := []byte{0xFE, 0x12, 0x0F}
+	rest := raw[18:]
+	n := min(7, len(rest))
+	enc = append(enc, rest[:n]...)
+	rest = rest[n:]
+	for len(rest) > 0 {
+		n := min(8, len(rest))
+		enc = append(enc, byte(1<<n-1))
+		enc = append(enc, rest[:n]...)
+		rest = rest[n:]
+	}
+	enc = binary.LittleEndian.AppendUint32(enc, uint32(sumUnsigned(raw))) // #nosec G115 -- checksum bit pattern
+
+	if dec, err := Decompress(enc, len(raw), nil); err != nil || !bytes.Equal(dec, raw) {
+		t.Fatalf("test block: err=%v", err)
+	}
+
+	data := append(binary.LittleEndian.AppendUint32(nil, uint32(len(raw))), enc...) // #nosec G115 -- test size
+	if results := Scan(data, ScanOptions{}); len(results) != 0 {
+		t.Fatalf("strict dialects found filler block: %+v", results)
+	}
+
+	lenient := []Options{{}}
+	for _, mode := range []ScanMode{ScanSizeFields, ScanBruteForce} {
+		results := Scan(data, ScanOptions{Options: lenient, Mode: mode})
+		if len(results) != 1 || results[0].Offset != 4 || results[0].OutLen != len(raw) || results[0].Consumed != len(enc) {
+			t.Fatalf("mode %d: %+v", mode, results)
+		}
+	}
+}
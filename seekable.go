@@ -0,0 +1,371 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 Maxim Levchenko (WoozyMasta)
+// Source: github.com/woozymasta/lzss
+
+package lzss
+
+import (
+	"container/list"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"sync"
+)
+
+// Seekable format constants.
+//
+// Layout (little-endian):
+//
+//	blocks   independent LZSS:8bit blocks (data + checksum), back-to-back
+//	index    count x {offset uint64, packed uint32, raw uint32}
+//	footer   count uint32, block size uint32, flags uint8, version uint8, reserved uint16, magic "LZSX"
+//
+// The footer is at the end, so the writer streams blocks without buffering the whole input.
+const (
+	// SeekableMagic identifies a seekable file (last 4 bytes).
+	SeekableMagic = "LZSX"
+
+	// SeekableVersion is the current seekable format version.
+	SeekableVersion = 1
+
+	// DefaultSeekableBlockSize is the default decoded size of one block.
+	DefaultSeekableBlockSize = 64 << 10
+
+	// DefaultSeekableCacheBlocks is the default number of decoded blocks kept in cache.
+	DefaultSeekableCacheBlocks = 8
+
+	// seekableFooterSize is the fixed footer size.
+	seekableFooterSize = 16
+
+	// seekableIndexEntrySize is the size of one index entry.
+	seekableIndexEntrySize = 16
+)
+
+// SeekableBlock is one index entry.
+type SeekableBlock struct {
+	Offset     uint64 // Offset of the LZSS block in the file.
+	PackedSize uint32 // Size of the LZSS block including checksum.
+	RawSize    uint32 // Decoded size of the block.
+}
+
+// SeekableWriter writes a seekable file: each block is compressed and written as soon as it is full,
+// the index and footer are written by Close.
+type SeekableWriter struct {
+	w    io.Writer
+	opts *CompressOptions
+
+	buf   []byte
+	index []SeekableBlock
+
+	offset uint64
+
+	// BlockSize is the decoded size of one block; zero means DefaultSeekableBlockSize.
+	// Set before the first Write.
+	BlockSize int
+
+	closed bool
+}
+
+// NewSeekableWriter returns a SeekableWriter writing to w. Options nil means DefaultCompressOptions().
+func NewSeekableWriter(w io.Writer, opts *CompressOptions) *SeekableWriter {
+	if opts == nil {
+		opts = DefaultCompressOptions()
+	}
+
+	return &SeekableWriter{w: w, opts: opts}
+}
+
+// Write buffers p and writes every full block.
+func (sw *SeekableWriter) Write(p []byte) (int, error) {
+	if sw.closed {
+		return 0, ErrWriterClosed
+	}
+
+	blockSize := sw.blockSize()
+	written := 0
+	for len(p) > 0 {
+		n := min(len(p), blockSize-len(sw.buf))
+		sw.buf = append(sw.buf, p[:n]...)
+		p = p[n:]
+		written += n
+
+		if len(sw.buf) == blockSize {
+			if err := sw.writeBlock(); err != nil {
+				return written, err
+			}
+		}
+	}
+
+	return written, nil
+}
+
+// Close writes the remaining block, index and footer. It does not close the underlying writer.
+func (sw *SeekableWriter) Close() error {
+	if sw.closed {
+		return ErrWriterClosed
+	}
+	sw.closed = true
+
+	if len(sw.buf) > 0 {
+		if err := sw.writeBlock(); err != nil {
+			return err
+		}
+	}
+	if uint64(len(sw.index)) > math.MaxUint32 {
+		return ErrFrameTooLarge
+	}
+
+	tail := make([]byte, 0, len(sw.index)*seekableIndexEntrySize+seekableFooterSize)
+	for _, b := range sw.index {
+		tail = binary.LittleEndian.AppendUint64(tail, b.Offset)
+		tail = binary.LittleEndian.AppendUint32(tail, b.PackedSize)
+		tail = binary.LittleEndian.AppendUint32(tail, b.RawSize)
+	}
+	tail = binary.LittleEndian.AppendUint32(tail, uint32(len(sw.index)))  // #nosec G115 -- range checked above
+	tail = binary.LittleEndian.AppendUint32(tail, uint32(sw.blockSize())) // #nosec G115 -- bounded by blockSize
+	tail = append(tail, frameFlags(sw.opts), SeekableVersion, 0, 0)
+	tail = append(tail, SeekableMagic...)
+
+	_, err := sw.w.Write(tail)
+
+	return err
+}
+
+// blockSize returns the effective block size.
+func (sw *SeekableWriter) blockSize() int {
+	if sw.BlockSize <= 0 || uint64(sw.BlockSize) > math.MaxUint32 {
+		return DefaultSeekableBlockSize
+	}
+
+	return sw.BlockSize
+}
+
+// writeBlock compresses and writes the buffered data as one block.
+func (sw *SeekableWriter) writeBlock() error {
+	enc, err := Compress(sw.buf, sw.opts)
+	if err != nil {
+		return err
+	}
+	if uint64(len(enc)) > math.MaxUint32 {
+		return ErrFrameTooLarge
+	}
+	if _, err := sw.w.Write(enc); err != nil {
+		return err
+	}
+
+	sw.index = append(sw.index, SeekableBlock{
+		Offset:     sw.offset,
+		PackedSize: uint32(len(enc)),    // #nosec G115 -- range checked above
+		RawSize:    uint32(len(sw.buf)), // #nosec G115 -- bounded by blockSize
+	})
+	sw.offset += uint64(len(enc))
+	sw.buf = sw.buf[:0]
+
+	return nil
+}
+
+// SeekableReader provides random access to a seekable file.
+// It implements io.Reader, io.ReaderAt and io.Seeker over decoded data and
+// decodes only blocks covering the requested range, keeping recently used blocks in an LRU cache.
+// ReadAt is safe for concurrent use; Read and Seek share a position and are not.
+type SeekableReader struct {
+	r    io.ReaderAt
+	opts *Options
+
+	cache    map[int]*list.Element
+	lru      *list.List
+	index    []SeekableBlock
+	starts   []int64 // Decoded start offset of each block.
+	size     int64
+	pos      int64
+	maxCache int
+
+	mu sync.Mutex
+}
+
+// seekableCacheEntry is one decoded block in the LRU list.
+type seekableCacheEntry struct {
+	data  []byte
+	block int
+}
+
+// NewSeekableReader reads the footer and index of a seekable file of size bytes from r.
+// cacheBlocks sets the LRU size in blocks; zero or negative means DefaultSeekableCacheBlocks.
+func NewSeekableReader(r io.ReaderAt, size int64, cacheBlocks int) (*SeekableReader, error) {
+	if r == nil {
+		return nil, ErrNilReader
+	}
+	if size < seekableFooterSize {
+		return nil, ErrSeekableMagic
+	}
+	if cacheBlocks <= 0 {
+		cacheBlocks = DefaultSeekableCacheBlocks
+	}
+
+	var footer [seekableFooterSize]byte
+	if _, err := r.ReadAt(footer[:], size-seekableFooterSize); err != nil {
+		return nil, err
+	}
+	if string(footer[12:16]) != SeekableMagic {
+		return nil, ErrSeekableMagic
+	}
+	if footer[9] != SeekableVersion {
+		return nil, fmt.Errorf("%w: %d", ErrFrameVersion, footer[9])
+	}
+	flags := footer[8]
+	if flags&^frameKnownFlags != 0 {
+		return nil, fmt.Errorf("%w: unknown flags 0x%02x", ErrSeekableCorrupt, flags)
+	}
+
+	count := int64(binary.LittleEndian.Uint32(footer[0:4]))
+	indexOffset := size - seekableFooterSize - count*seekableIndexEntrySize
+	if indexOffset < 0 {
+		return nil, fmt.Errorf("%w: index of %d blocks exceeds file size", ErrSeekableCorrupt, count)
+	}
+
+	raw := make([]byte, count*seekableIndexEntrySize)
+	if _, err := r.ReadAt(raw, indexOffset); err != nil {
+		return nil, err
+	}
+
+	sr := &SeekableReader{
+		r:        r,
+		opts:     flagsOptions(flags),
+		cache:    make(map[int]*list.Element, cacheBlocks),
+		lru:      list.New(),
+		index:    make([]SeekableBlock, count),
+		starts:   make([]int64, count),
+		maxCache: cacheBlocks,
+	}
+	for i := range sr.index {
+		entry := raw[i*seekableIndexEntrySize:]
+		b := SeekableBlock{
+			Offset:     binary.LittleEndian.Uint64(entry[0:8]),
+			PackedSize: binary.LittleEndian.Uint32(entry[8:12]),
+			RawSize:    binary.LittleEndian.Uint32(entry[12:16]),
+		}
+		if b.Offset+uint64(b.PackedSize) > uint64(indexOffset) {
+			return nil, fmt.Errorf("%w: block %d out of range", ErrSeekableCorrupt, i)
+		}
+		if int64(b.RawSize) > MaxDecodedSize(int64(b.PackedSize), sr.opts) {
+			return nil, fmt.Errorf("%w: block %d raw=%d packed=%d", ErrSeekableCorrupt, i, b.RawSize, b.PackedSize)
+		}
+
+		sr.index[i] = b
+		sr.starts[i] = sr.size
+		sr.size += int64(b.RawSize)
+	}
+
+	return sr, nil
+}
+
+// Size returns the decoded size.
+func (sr *SeekableReader) Size() int64 {
+	return sr.size
+}
+
+// Blocks returns the block index.
+func (sr *SeekableReader) Blocks() []SeekableBlock {
+	return sr.index
+}
+
+// ReadAt implements io.ReaderAt over decoded data.
+func (sr *SeekableReader) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, ErrNegativeOffset
+	}
+	if off >= sr.size {
+		return 0, io.EOF
+	}
+
+	n := 0
+	for n < len(p) && off < sr.size {
+		i := sort.Search(len(sr.starts), func(i int) bool { return sr.starts[i] > off }) - 1
+		data, err := sr.block(i)
+		if err != nil {
+			return n, err
+		}
+
+		copied := copy(p[n:], data[off-sr.starts[i]:])
+		n += copied
+		off += int64(copied)
+	}
+
+	if n < len(p) {
+		return n, io.EOF
+	}
+
+	return n, nil
+}
+
+// Read implements io.Reader.
+func (sr *SeekableReader) Read(p []byte) (int, error) {
+	if sr.pos >= sr.size {
+		return 0, io.EOF
+	}
+
+	n, err := sr.ReadAt(p, sr.pos)
+	sr.pos += int64(n)
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+
+	return n, err
+}
+
+// Seek implements io.Seeker over decoded data.
+func (sr *SeekableReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += sr.pos
+	case io.SeekEnd:
+		offset += sr.size
+	default:
+		return 0, ErrInvalidWhence
+	}
+	if offset < 0 {
+		return 0, ErrNegativeOffset
+	}
+	sr.pos = offset
+
+	return offset, nil
+}
+
+// block returns decoded block i from cache or decodes it.
+func (sr *SeekableReader) block(i int) ([]byte, error) {
+	sr.mu.Lock()
+	if elem, ok := sr.cache[i]; ok {
+		sr.lru.MoveToFront(elem)
+		data := elem.Value.(*seekableCacheEntry).data
+		sr.mu.Unlock()
+		return data, nil
+	}
+	sr.mu.Unlock()
+
+	b := sr.index[i]
+	packed := make([]byte, b.PackedSize)
+	if _, err := sr.r.ReadAt(packed, int64(b.Offset)); err != nil { // #nosec G115 -- checked against file size
+		return nil, err
+	}
+
+	data, err := Decompress(packed, int(b.RawSize), sr.opts)
+	if err != nil {
+		return nil, withBlock(err, i, int64(b.Offset)) // #nosec G115 -- checked against file size
+	}
+
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	if _, ok := sr.cache[i]; !ok {
+		sr.cache[i] = sr.lru.PushFront(&seekableCacheEntry{block: i, data: data})
+		for sr.lru.Len() > sr.maxCache {
+			oldest := sr.lru.Back()
+			sr.lru.Remove(oldest)
+			delete(sr.cache, oldest.Value.(*seekableCacheEntry).block)
+		}
+	}
+
+	return data, nil
+}
@@ -0,0 +1,159 @@
+package lzss
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"sync"
+	"testing"
+	"testing/iotest"
+)
+
+// seekableTestData returns compressible data of n bytes with position-dependent content.
+func seekableTestData(n int) []byte {
+	rng := rand.New(rand.NewPCG(3, 4))
+	words := [][]byte{[]byte("alpha "), []byte("beta "), []byte("gamma "), []byte("delta ")}
+	data := make([]byte, 0, n+8)
+	for len(data) < n {
+		data = append(data, words[rng.IntN(len(words))]...)
+	}
+
+	return data[:n]
+}
+
+func writeSeekable(t *testing.T, data []byte, blockSize int, opts *CompressOptions) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	sw := NewSeekableWriter(&buf, opts)
+	sw.BlockSize = blockSize
+	if _, err := sw.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := sw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestSeekableReader(t *testing.T) {
+	data := seekableTestData(100_000)
+	file := writeSeekable(t, data, 4096, nil)
+
+	sr, err := NewSeekableReader(bytes.NewReader(file), int64(len(file)), 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sr.Size() != int64(len(data)) || len(sr.Blocks()) != 25 {
+		t.Fatalf("size=%d blocks=%d", sr.Size(), len(sr.Blocks()))
+	}
+
+	if err := iotest.TestReader(sr, data); err != nil {
+		t.Fatal(err)
+	}
+
+	// Range across block boundary decodes two blocks only.
+	sr, err = NewSeekableReader(bytes.NewReader(file), int64(len(file)), 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 100)
+	if _, err := sr.ReadAt(buf, 4096*3-50); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf, data[4096*3-50:4096*3+50]) {
+		t.Fatal("range mismatch")
+	}
+	if sr.lru.Len() != 2 {
+		t.Fatalf("cached blocks=%d", sr.lru.Len())
+	}
+
+	// Cache keeps at most 4 blocks.
+	for off := int64(0); off < sr.Size(); off += 4096 {
+		if _, err := sr.ReadAt(buf[:1], off); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if sr.lru.Len() != 4 || len(sr.cache) != 4 {
+		t.Fatalf("cached blocks=%d map=%d", sr.lru.Len(), len(sr.cache))
+	}
+}
+
+func TestSeekableReaderConcurrentReadAt(t *testing.T) {
+	data := seekableTestData(50_000)
+	file := writeSeekable(t, data, 1000, &CompressOptions{Checksum: ChecksumSigned, SearchLimit: 1024, MinMatchLength: MinMatch2})
+
+	sr, err := NewSeekableReader(bytes.NewReader(file), int64(len(file)), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rng := rand.New(rand.NewPCG(uint64(g), 1))
+			buf := make([]byte, 700)
+			for range 200 {
+				off := rng.Int64N(int64(len(data) - len(buf)))
+				if _, err := sr.ReadAt(buf, off); err != nil {
+					errs <- err
+					return
+				}
+				if !bytes.Equal(buf, data[off:off+int64(len(buf))]) {
+					errs <- errors.New("content mismatch")
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+}
+
+func TestSeekableReaderErrors(t *testing.T) {
+	data := seekableTestData(10_000)
+	file := writeSeekable(t, data, 2048, nil)
+
+	if _, err := NewSeekableReader(bytes.NewReader(file[:len(file)-1]), int64(len(file)-1), 0); !errors.Is(err, ErrSeekableMagic) {
+		t.Fatalf("want ErrSeekableMagic, got %v", err)
+	}
+
+	// A raw size the block cannot expand to is rejected when the index is read.
+	count := int(binary.LittleEndian.Uint32(file[len(file)-seekableFooterSize:]))
+	badRaw := bytes.Clone(file)
+	binary.LittleEndian.PutUint32(badRaw[len(file)-seekableFooterSize-count*seekableIndexEntrySize+12:], 0xFFFFFFFF)
+	if _, err := NewSeekableReader(bytes.NewReader(badRaw), int64(len(badRaw)), 0); !errors.Is(err, ErrSeekableCorrupt) {
+		t.Fatalf("huge raw size: want ErrSeekableCorrupt, got %v", err)
+	}
+
+	corrupt := bytes.Clone(file)
+	corrupt[10] ^= 0xFF
+	sr, err := NewSeekableReader(bytes.NewReader(corrupt), int64(len(corrupt)), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = sr.ReadAt(make([]byte, 10), 0)
+	var decErr *DecodeError
+	if !errors.As(err, &decErr) || decErr.Block != 0 {
+		t.Fatalf("want *DecodeError for block 0, got %v", err)
+	}
+	if _, err := sr.ReadAt(make([]byte, 10), 5000); err != nil {
+		t.Fatalf("other blocks must stay readable: %v", err)
+	}
+
+	if _, err := sr.Seek(-1, io.SeekStart); !errors.Is(err, ErrNegativeOffset) {
+		t.Fatalf("want ErrNegativeOffset, got %v", err)
+	}
+	if _, err := sr.Seek(0, 7); !errors.Is(err, ErrInvalidWhence) {
+		t.Fatalf("want ErrInvalidWhence, got %v", err)
+	}
+}
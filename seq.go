@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 Maxim Levchenko (WoozyMasta)
+// Source: github.com/woozymasta/lzss
+
+package lzss
+
+import (
+	"io"
+	"iter"
+)
+
+// Block is one decoded block yielded by DecompressSeq and DecompressNSeq.
+type Block struct {
+	Data     []byte // Decoded data; owned by the caller.
+	Index    int    // Block index, starting at 0.
+	Offset   int64  // Input offset of the block start, relative to the first byte read from r.
+	Consumed int64  // Compressed size of the block including checksum.
+}
+
+// DecompressSeq returns an iterator that decodes blocks from r one at a time
+// while nextOutLen returns (outLen, true). Only the current block is held in memory;
+// breaking out of the loop stops reading. A decode error is yielded once
+// with the failing block's Index and Offset, then iteration ends.
+func DecompressSeq(r io.Reader, nextOutLen func() (int, bool), opts *Options) iter.Seq2[Block, error] {
+	return func(yield func(Block, error) bool) {
+		if nextOutLen == nil {
+			yield(Block{}, ErrNilOutLenProvider)
+			return
+		}
+
+		decodeBlocks(r, func(int) (int, bool) { return nextOutLen() }, opts, yield)
+	}
+}
+
+// DecompressNSeq returns an iterator that decodes len(outLens) blocks from r one at a time.
+// Semantics match DecompressSeq.
+func DecompressNSeq(r io.Reader, outLens []int, opts *Options) iter.Seq2[Block, error] {
+	return func(yield func(Block, error) bool) {
+		decodeBlocks(r, func(i int) (int, bool) {
+			if i >= len(outLens) {
+				return 0, false
+			}
+
+			return outLens[i], true
+		}, opts, yield)
+	}
+}
+
+// decodeBlocks decodes blocks while outLen(i) returns true and passes them to yield.
+func decodeBlocks(r io.Reader, outLen func(i int) (int, bool), opts *Options, yield func(Block, error) bool) {
+	countingReader, err := newCountingByteReader(r)
+	if err != nil {
+		yield(Block{}, err)
+		return
+	}
+
+	for i := 0; ; i++ {
+		n, ok := outLen(i)
+		if !ok {
+			return
+		}
+
+		base := countingReader.count
+		data, _, decodeErr := decompressFromByteReader(countingReader, n, opts)
+		if decodeErr != nil {
+			yield(Block{Index: i, Offset: base}, releaseReader(countingReader, withBlock(decodeErr, i, base)))
+			return
+		}
+
+		// Return read-ahead before yielding, so r is positioned after the block if the loop breaks.
+		if err := countingReader.release(); err != nil {
+			yield(Block{Index: i, Offset: base}, err)
+			return
+		}
+
+		block := Block{Data: data, Index: i, Offset: base, Consumed: countingReader.count - base}
+		if !yield(block, nil) {
+			return
+		}
+	}
+}
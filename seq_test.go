@@ -0,0 +1,114 @@
+package lzss
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func seqTestStream(t *testing.T, raws ...string) ([]byte, []int, []int) {
+	t.Helper()
+
+	var stream []byte
+	outLens := make([]int, 0, len(raws))
+	packed := make([]int, 0, len(raws))
+	for _, raw := range raws {
+		enc, err := Compress([]byte(raw), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		stream = append(stream, enc...)
+		outLens = append(outLens, len(raw))
+		packed = append(packed, len(enc))
+	}
+
+	return stream, outLens, packed
+}
+
+func TestDecompressNSeq(t *testing.T) {
+	raws := []string{"seq block one", "seq block two, two, two", "seq block three"}
+	stream, outLens, packed := seqTestStream(t, raws...)
+
+	var offset int64
+	count := 0
+	for block, err := range DecompressNSeq(bytes.NewReader(stream), outLens, nil) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if block.Index != count || block.Offset != offset || block.Consumed != int64(packed[count]) {
+			t.Fatalf("block %+v offset=%d", block, offset)
+		}
+		if string(block.Data) != raws[count] {
+			t.Fatalf("block %d data=%q", count, block.Data)
+		}
+
+		offset += block.Consumed
+		count++
+	}
+	if count != len(raws) {
+		t.Fatalf("blocks=%d", count)
+	}
+}
+
+func TestDecompressSeqEarlyBreak(t *testing.T) {
+	stream, outLens, packed := seqTestStream(t, "first block", "second block", "third block")
+
+	calls := 0
+	next := func() (int, bool) {
+		calls++
+		if calls > len(outLens) {
+			return 0, false
+		}
+
+		return outLens[calls-1], true
+	}
+
+	r := bytes.NewReader(stream)
+	for block, err := range DecompressSeq(r, next, nil) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if block.Index == 1 {
+			break
+		}
+	}
+
+	if calls != 2 {
+		t.Fatalf("provider calls=%d", calls)
+	}
+	if r.Len() != packed[2] {
+		t.Fatalf("unread=%d want=%d", r.Len(), packed[2])
+	}
+}
+
+func TestDecompressSeqError(t *testing.T) {
+	stream, outLens, packed := seqTestStream(t, "good block", "truncated block")
+	stream = stream[:len(stream)-3]
+
+	var got []Block
+	var gotErr error
+	for block, err := range DecompressNSeq(bytes.NewReader(stream), outLens, nil) {
+		if err != nil {
+			gotErr = err
+		}
+		got = append(got, block)
+	}
+
+	if len(got) != 2 || !errors.Is(gotErr, ErrInputTooShort) {
+		t.Fatalf("blocks=%d err=%v", len(got), gotErr)
+	}
+
+	var decodeErr *DecodeError
+	if !errors.As(gotErr, &decodeErr) || decodeErr.Block != 1 {
+		t.Fatalf("err=%v", gotErr)
+	}
+	if got[1].Index != 1 || got[1].Offset != int64(packed[0]) || got[1].Data != nil {
+		t.Fatalf("failed block=%+v", got[1])
+	}
+
+	for _, err := range DecompressSeq(bytes.NewReader(stream), nil, nil) {
+		if !errors.Is(err, ErrNilOutLenProvider) {
+			t.Fatalf("nil provider: %v", err)
+		}
+	}
+}
@@ -0,0 +1,281 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 Maxim Levchenko (WoozyMasta)
+// Source: github.com/woozymasta/lzss
+
+package lzss
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// windowMask maps output positions to the ring buffer.
+const windowMask = WindowSize - 1
+
+// Reader decodes one LZSS block incrementally from an underlying stream.
+// Decoded bytes go through a 4 KiB ring buffer, so memory use does not depend on outLen.
+// The checksum is read and verified when the last byte is decoded; Read then returns io.EOF
+// or a *DecodeError wrapping *ChecksumError in strict mode.
+type Reader struct {
+	src  *countingByteReader
+	opts *Options
+	err  error
+
+	info BlockInfo
+
+	outLen   int
+	pos      int // Decoded bytes.
+	read     int // Bytes returned to the caller; pos-read bytes are pending in window.
+	minMatch int
+	bit      int // Next slot in flag; FlagBits means a new flag byte is needed.
+
+	crc    int32
+	flag   byte
+	closed bool
+
+	window [WindowSize]byte
+}
+
+// NewReader returns a Reader decoding one block of outLen bytes from r.
+// Options nil means DefaultOptions (unsigned checksum, strict verification).
+func NewReader(r io.Reader, outLen int, opts *Options) (*Reader, error) {
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+	if outLen < 0 {
+		return nil, ErrNegativeOutLen
+	}
+
+	src, err := newCountingByteReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	minMatch := opts.MinMatchLength
+	if minMatch == 0 {
+		minMatch = MinMatchDefault
+	}
+
+	return &Reader{
+		src:      src,
+		opts:     opts,
+		outLen:   outLen,
+		minMatch: minMatch,
+		bit:      FlagBits,
+	}, nil
+}
+
+// Read implements io.Reader.
+func (r *Reader) Read(p []byte) (int, error) {
+	if r.closed {
+		return 0, ErrReaderClosed
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	for r.pos == r.read {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.pos == r.outLen {
+			r.err = r.finish()
+			r.release()
+			continue
+		}
+
+		// Keep room for one token so pending bytes are not overwritten.
+		for r.pos < r.outLen && r.pos-r.read < len(p) && r.pos-r.read <= WindowSize-2*MaxMatch {
+			if err := r.step(); err != nil {
+				r.err = err
+				r.release()
+				break
+			}
+		}
+	}
+
+	n := 0
+	for n < len(p) && r.read < r.pos {
+		start := r.read & windowMask
+		end := min(start+r.pos-r.read, WindowSize)
+		copied := copy(p[n:], r.window[start:end])
+		n += copied
+		r.read += copied
+	}
+
+	return n, nil
+}
+
+// Close stops decoding early: the rest of the block and its checksum are not read,
+// so no checksum error is reported. Read-ahead is returned to the underlying reader
+// as after io.EOF, leaving it after the last decoded token; it is not closed.
+// Read after Close returns ErrReaderClosed.
+func (r *Reader) Close() error {
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	if err := r.src.release(); err != nil {
+		return &DecodeError{Err: err, InOffset: r.src.count, OutOffset: r.pos, Bit: -1}
+	}
+
+	return nil
+}
+
+// Consumed returns the number of input bytes read so far.
+func (r *Reader) Consumed() int64 {
+	return r.src.count
+}
+
+// Info returns consumed bytes and checksum result; checksum fields are set after io.EOF.
+func (r *Reader) Info() BlockInfo {
+	info := r.info
+	info.Consumed = r.src.count
+
+	return info
+}
+
+// fail wraps err with the current decoder position.
+func (r *Reader) fail(err error, bit int) error {
+	return &DecodeError{
+		Err:       err,
+		InOffset:  r.src.count,
+		OutOffset: min(r.pos, r.outLen),
+		Flag:      r.flag,
+		Bit:       bit,
+	}
+}
+
+// release returns read-ahead to the source once decoding ended; a seek error replaces io.EOF.
+func (r *Reader) release() {
+	if err := r.src.release(); err != nil && r.err == io.EOF {
+		r.err = &DecodeError{Err: err, InOffset: r.src.count, OutOffset: r.pos, Bit: -1}
+	}
+}
+
+// readByte reads one input byte, mapping io.EOF to eofErr.
+func (r *Reader) readByte(eofErr error, bit int) (byte, error) {
+	b, err := r.src.ReadByte()
+	if err != nil {
+		if err == io.EOF {
+			return 0, r.fail(eofErr, bit)
+		}
+
+		return 0, r.fail(err, bit)
+	}
+
+	return b, nil
+}
+
+// put appends one decoded byte to the window and checksum.
+func (r *Reader) put(b byte) {
+	r.window[r.pos&windowMask] = b
+	if r.opts.Checksum == ChecksumSigned {
+		r.crc += int32(int8(b))
+	} else {
+		r.crc += int32(b)
+	}
+	r.pos++
+}
+
+// step decodes one slot (literal or pointer), reading a new flag byte when needed.
+func (r *Reader) step() error {
+	if r.bit == FlagBits {
+		flag, err := r.readByte(ErrUnexpectedEOF, -1)
+		if err != nil {
+			return err
+		}
+		r.flag = flag
+		r.bit = 0
+	}
+
+	slot := r.bit
+	if (r.flag>>slot)&1 == 1 {
+		b, err := r.readByte(ErrUnexpectedEOFBit, slot)
+		if err != nil {
+			return err
+		}
+		r.put(b)
+	} else if err := r.pointer(slot); err != nil {
+		return err
+	}
+	r.bit++
+
+	// Unused slots of the final flag group must be zero in strict mode.
+	if r.pos == r.outLen && r.opts.Strict && slot < FlagBits-1 && r.flag>>(slot+1) != 0 {
+		return r.fail(ErrFlagBitsBeyondEnd, slot)
+	}
+
+	return nil
+}
+
+// pointer decodes one back-reference in slot.
+func (r *Reader) pointer(slot int) error {
+	lo, err := r.readByte(ErrUnexpectedEOFBit, slot)
+	if err != nil {
+		return err
+	}
+	hi, err := r.readByte(ErrUnexpectedEOFBit, slot)
+	if err != nil {
+		return err
+	}
+
+	pointer := uint16(lo) | (uint16(hi) << 8)
+	offset := int(pointer&0xFF) | int((pointer&0xF000)>>4)
+	length := int((pointer&0x0F00)>>8) + r.minMatch
+
+	if r.opts.Strict {
+		switch {
+		case offset == 0:
+			return r.fail(ErrZeroOffset, slot)
+		case r.pos-offset < 0:
+			return r.fail(ErrFillerRef, slot)
+		case r.pos+length > r.outLen:
+			return r.fail(ErrMatchOverrun, slot)
+		}
+	}
+
+	// Byte-by-byte copy handles overlap; bytes before output start are Filler.
+	// Offset 0 refers to output not yet written, which decodes as zero.
+	for range length {
+		if r.pos >= r.outLen {
+			break
+		}
+
+		var b byte
+		switch src := r.pos - offset; {
+		case src < 0:
+			b = Filler
+		case offset > 0:
+			b = r.window[src&windowMask]
+		}
+		r.put(b)
+	}
+
+	return nil
+}
+
+// finish reads the trailing checksum and verifies it; returns io.EOF on success.
+func (r *Reader) finish() error {
+	var checksumBytes [4]byte
+	for i := range checksumBytes {
+		b, err := r.readByte(ErrInputTooShort, -1)
+		if err != nil {
+			return err
+		}
+		checksumBytes[i] = b
+	}
+
+	r.info = BlockInfo{
+		StoredChecksum:   binary.LittleEndian.Uint32(checksumBytes[:]),
+		ComputedChecksum: uint32(r.crc), // #nosec G115 -- checksum bit pattern
+		Mode:             r.opts.Checksum,
+	}
+	r.info.ChecksumMatch = r.info.StoredChecksum == r.info.ComputedChecksum
+
+	if r.opts.VerifyChecksum && !r.info.ChecksumMatch {
+		return r.fail(r.info.ChecksumError(), -1)
+	}
+
+	return io.EOF
+}
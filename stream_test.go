@@ -0,0 +1,132 @@
+package lzss
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestReaderMatchesDecompress(t *testing.T) {
+	inputs := [][]byte{
+		[]byte("x"),
+		bytes.Repeat([]byte("streaming reader payload "), 1000),
+		seekableTestData(20_000),
+	}
+	for _, input := range inputs {
+		enc, err := Compress(input, &CompressOptions{SearchLimit: 4096})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r, err := NewReader(bytes.NewReader(enc), len(input), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, input) {
+			t.Fatalf("len=%d: content mismatch", len(input))
+		}
+		if info := r.Info(); info.Consumed != int64(len(enc)) || !info.ChecksumMatch {
+			t.Fatalf("info=%+v", info)
+		}
+	}
+}
+
+func TestReaderChecksumError(t *testing.T) {
+	input := []byte("reader checksum error")
+	enc, err := Compress(input, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc[len(enc)-1] ^= 0x10
+
+	r, err := NewReader(bytes.NewReader(enc), len(input), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(r)
+	if !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("want ErrChecksumMismatch, got %v", err)
+	}
+	if !bytes.Equal(got, input) {
+		t.Fatal("decoded bytes must be returned before checksum error")
+	}
+}
+
+func TestDecompressPrefix(t *testing.T) {
+	raw := append([]byte("PAA\x01 header"), bytes.Repeat([]byte("texture payload "), 500)...)
+	enc, err := Compress(raw, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The checksum is never read, so a damaged one does not matter.
+	enc[len(enc)-1] ^= 0xFF
+
+	for _, n := range []int{0, 1, 10, 64, 4096} {
+		prefix, in, err := DecompressPrefix(enc, len(raw), n, nil)
+		if err != nil {
+			t.Fatalf("n=%d: %v", n, err)
+		}
+		if !bytes.Equal(prefix, raw[:n]) {
+			t.Fatalf("n=%d: prefix=%q", n, prefix)
+		}
+		if in >= len(enc)-4 || (n > 0 && in == 0) {
+			t.Fatalf("n=%d: input offset=%d of %d", n, in, len(enc))
+		}
+	}
+
+	prefix, in, err := DecompressPrefix(enc, len(raw), len(raw)+10, nil)
+	if err != nil || !bytes.Equal(prefix, raw) || in != len(enc)-4 {
+		t.Fatalf("full: len=%d in=%d err=%v", len(prefix), in, err)
+	}
+
+	prefix, _, err = DecompressPrefix(enc[:20], len(raw), 100, nil)
+	if !errors.Is(err, ErrUnexpectedEOFBit) && !errors.Is(err, ErrUnexpectedEOF) {
+		t.Fatalf("truncated: %v", err)
+	}
+	if !bytes.Equal(prefix, raw[:len(prefix)]) {
+		t.Fatalf("truncated prefix=%q", prefix)
+	}
+}
+
+func TestReaderCloseEarly(t *testing.T) {
+	raw := bytes.Repeat([]byte("close before the checksum "), 400)
+	enc, err := Compress(raw, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc[len(enc)-1] ^= 0xFF
+	data := append(enc, "tail"...)
+
+	for name, open := range exactSources(t, data) {
+		src := open()
+		zr, err := NewReader(src, len(raw), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		head := make([]byte, 32)
+		if _, err := io.ReadFull(zr, head); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if err := zr.Close(); err != nil {
+			t.Fatalf("%s: close: %v", name, err)
+		}
+		if _, err := zr.Read(head); !errors.Is(err, ErrReaderClosed) {
+			t.Fatalf("%s: read after close: %v", name, err)
+		}
+
+		// The source continues right after the last decoded token.
+		rest, err := io.ReadAll(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if int64(len(rest)) != int64(len(data))-zr.Consumed() {
+			t.Fatalf("%s: rest=%d consumed=%d total=%d", name, len(rest), zr.Consumed(), len(data))
+		}
+	}
+}
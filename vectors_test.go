@@ -0,0 +1,75 @@
+package lzss
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const vectorsDir = "testdata/vectors"
+
+// formatVector describes one entry of testdata/vectors/manifest.json.
+type formatVector struct {
+	Name           string `json:"name"`
+	Source         string `json:"source"`
+	Note           string `json:"note"`
+	Compressed     string `json:"compressed"`
+	Decoded        string `json:"decoded"`
+	Checksum       string `json:"checksum"`
+	MinMatch       int    `json:"min_match"`
+	VerifyChecksum bool   `json:"verify_checksum"`
+}
+
+func loadFormatVectors(t *testing.T) []formatVector {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join(vectorsDir, "manifest.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var vectors []formatVector
+	if err := json.Unmarshal(data, &vectors); err != nil {
+		t.Fatal(err)
+	}
+
+	return vectors
+}
+
+// TestFormatVectors decodes hand-assembled format vectors; see testdata/vectors/README.md.
+func TestFormatVectors(t *testing.T) {
+	for _, v := range loadFormatVectors(t) {
+		t.Run(v.Name, func(t *testing.T) {
+			compressed, err := os.ReadFile(filepath.Join(vectorsDir, v.Compressed))
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded, err := os.ReadFile(filepath.Join(vectorsDir, v.Decoded))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			mode := ChecksumUnsigned
+			if v.Checksum == "signed" {
+				mode = ChecksumSigned
+			}
+			opts := &Options{Checksum: mode, VerifyChecksum: v.VerifyChecksum, MinMatchLength: v.MinMatch}
+
+			out, info, err := DecompressBlockInfo(compressed, len(decoded), opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(out, decoded) {
+				t.Fatal("decoded output differs from expected")
+			}
+			if info.Consumed != int64(len(compressed)) {
+				t.Fatalf("consumed=%d want=%d", info.Consumed, len(compressed))
+			}
+			if v.VerifyChecksum && !info.ChecksumMatch {
+				t.Fatalf("checksum mismatch: %+v", info)
+			}
+		})
+	}
+}
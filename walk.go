@@ -0,0 +1,194 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 Maxim Levchenko (WoozyMasta)
+// Source: github.com/woozymasta/lzss
+
+package lzss
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// token is one slot of a flag group: a literal byte or a back-reference.
+type token struct {
+	literal bool
+	b       byte // Literal byte.
+	offset  int  // Pointer offset backward from the output position (0..4095).
+	length  int  // Pointer length (minMatch..minMatch+15).
+}
+
+// tokenWalker reads the tokens of one block from a byte reader and applies the dialect rules
+// (flag bit order, pointer layout, min match length, Strict checks) in one place.
+// Decoders that read from io.ByteReader, BlockSize, Scan and Analyze walk blocks with it;
+// decompressSlice and Reader.step keep their own loops for speed and resumable state,
+// and the fuzz targets check that they agree with it.
+type tokenWalker struct {
+	r        io.ByteReader
+	minMatch int
+	strict   bool
+
+	end int // Output position after the token returned by next, applied on the following call.
+
+	// Decoder position, reported in DecodeError.
+	inPos    int64
+	pos      int
+	flagByte byte
+	bit      int
+}
+
+// newTokenWalker returns a walker over r with options opts (nil means DefaultOptions()).
+func newTokenWalker(r io.ByteReader, opts *Options) *tokenWalker {
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+
+	return &tokenWalker{
+		r:        r,
+		minMatch: normalizeMinMatch(opts.MinMatchLength),
+		strict:   opts.Strict,
+		bit:      -1,
+	}
+}
+
+// fail wraps err in *DecodeError at the current position.
+func (w *tokenWalker) fail(err error) error {
+	return &DecodeError{Err: err, InOffset: w.inPos, OutOffset: w.pos, Flag: w.flagByte, Bit: w.bit}
+}
+
+// readByte reads one byte; io.EOF is reported as eofErr, other reader errors as they are.
+func (w *tokenWalker) readByte(eofErr error) (byte, error) {
+	b, err := w.r.ReadByte()
+	if err != nil {
+		return 0, w.readError(err, eofErr)
+	}
+
+	w.inPos++
+
+	return b, nil
+}
+
+// readError wraps a reader error; kept out of readByte so that it inlines.
+func (w *tokenWalker) readError(err, eofErr error) error {
+	if err == io.EOF {
+		return w.fail(eofErr)
+	}
+
+	return w.fail(err)
+}
+
+// next returns the next token of a block decoding to outLen bytes; w.pos is its output position.
+// It returns false once outLen bytes are produced; call checksum then.
+// Positions advance as the decoder writes: a filler run (offset before output start)
+// is not capped, copied bytes stop at outLen.
+func (w *tokenWalker) next(outLen int) (token, bool, error) {
+	if w.bit >= 0 {
+		w.pos = w.end
+		if w.pos >= outLen {
+			// Unused slots of the final flag group must be zero in strict mode.
+			if w.strict && w.bit < FlagBits-1 && w.flagByte>>(w.bit+1) != 0 {
+				return token{}, false, w.fail(ErrFlagBitsBeyondEnd)
+			}
+
+			return token{}, false, nil
+		}
+	}
+	if w.pos >= outLen {
+		return token{}, false, nil
+	}
+
+	if w.bit < 0 || w.bit == FlagBits-1 {
+		w.bit = -1
+		flag, err := w.readByte(ErrUnexpectedEOF)
+		if err != nil {
+			return token{}, false, err
+		}
+		w.flagByte = flag
+	}
+	w.bit++
+
+	var t token
+	var err error
+	if (w.flagByte>>w.bit)&1 == 1 {
+		t.literal, t.length = true, 1
+		t.b, err = w.readByte(ErrUnexpectedEOFBit)
+	} else {
+		t, err = w.pointer(outLen)
+	}
+	if err != nil {
+		return token{}, false, err
+	}
+
+	w.end = w.pos + 1
+	if !t.literal {
+		fill := min(max(t.offset-w.pos, 0), t.length)
+		w.end = w.pos + fill
+		if fill < t.length && w.end < outLen {
+			w.end = min(w.end+t.length-fill, outLen)
+		}
+	}
+
+	return t, true, nil
+}
+
+// checksum reads the 4-byte checksum after next returned false and returns it.
+func (w *tokenWalker) checksum(outLen int) (uint32, error) {
+	// Output position may exceed outLen after a filler run; report the block end.
+	w.pos = min(w.pos, outLen)
+	w.bit = -1
+
+	var sum [4]byte
+	for i := range sum {
+		b, err := w.readByte(ErrInputTooShort)
+		if err != nil {
+			return 0, err
+		}
+		sum[i] = b
+	}
+
+	return binary.LittleEndian.Uint32(sum[:]), nil
+}
+
+// walk reads all tokens and the checksum of a block decoding to outLen bytes.
+func (w *tokenWalker) walk(outLen int) (uint32, error) {
+	for {
+		_, ok, err := w.next(outLen)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			return w.checksum(outLen)
+		}
+	}
+}
+
+// pointer reads the back-reference of the current flag bit and checks it against Strict rules.
+func (w *tokenWalker) pointer(outLen int) (token, error) {
+	lo, err := w.readByte(ErrUnexpectedEOFBit)
+	if err != nil {
+		return token{}, err
+	}
+	hi, err := w.readByte(ErrUnexpectedEOFBit)
+	if err != nil {
+		return token{}, err
+	}
+
+	// Pointer: LE 16-bit = [offset_lo8, (offset_hi4<<4)|(length-minMatch)]; offset is backward from pos.
+	pointer := uint16(lo) | uint16(hi)<<8
+	t := token{
+		offset: int(pointer&0xFF) | int((pointer&0xF000)>>4),
+		length: int((pointer&0x0F00)>>8) + w.minMatch,
+	}
+
+	if w.strict {
+		switch {
+		case t.offset == 0:
+			return token{}, w.fail(ErrZeroOffset)
+		case w.pos-t.offset < 0:
+			return token{}, w.fail(ErrFillerRef)
+		case w.pos+t.length > outLen:
+			return token{}, w.fail(ErrMatchOverrun)
+		}
+	}
+
+	return t, nil
+}
@@ -0,0 +1,177 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 Maxim Levchenko (WoozyMasta)
+// Source: github.com/woozymasta/lzss
+
+package lzss
+
+import (
+	"fmt"
+	"io"
+)
+
+// CompressNToWriter compresses each of blocks independently and writes them back-to-back to w,
+// each with its own checksum. It returns compressed sizes of the written blocks;
+// the result can be read back with DecompressNFromReader using len(blocks[i]) as outLens.
+// Options nil means DefaultCompressOptions(). Empty blocks are rejected with ErrEmptyInput.
+func CompressNToWriter(w io.Writer, blocks [][]byte, opts *CompressOptions) ([]int, error) {
+	sizes := make([]int, 0, len(blocks))
+	for i, block := range blocks {
+		enc, err := Compress(block, opts)
+		if err != nil {
+			return sizes, fmt.Errorf("block %d: %w", i, err)
+		}
+		if _, err := w.Write(enc); err != nil {
+			return sizes, err
+		}
+
+		sizes = append(sizes, len(enc))
+	}
+
+	return sizes, nil
+}
+
+// compressChunk is the read size of CompressToWriter.
+const compressChunk = 64 << 10
+
+// CompressToWriter compresses all of r as one block and writes it to w.
+// Output equals Compress of the same data; only the match window and one read chunk are
+// held in memory. It returns the number of bytes written.
+// Options nil means DefaultCompressOptions(). Empty input is rejected with ErrEmptyInput.
+func CompressToWriter(w io.Writer, r io.Reader, opts *CompressOptions) (int64, error) {
+	if opts == nil {
+		opts = DefaultCompressOptions()
+	}
+
+	e := newEncoder(opts, nil)
+	maxEncLen := e.minMatch + 15
+	buf := make([]byte, 0, e.limit+maxEncLen+compressChunk)
+
+	var crc int32
+	var read, written int64
+	i := 0
+	for eof := false; !eof; {
+		n, err := r.Read(buf[len(buf):cap(buf)])
+		if err == io.EOF {
+			eof = true
+		} else if err != nil {
+			return written, err
+		}
+
+		crc += e.checksum(buf[len(buf) : len(buf)+n])
+		buf = buf[:len(buf)+n]
+		read += int64(n)
+
+		// Positions need maxEncLen bytes ahead to find the same matches as Compress.
+		stop := len(buf)
+		if !eof {
+			stop -= maxEncLen
+		}
+		if stop > i {
+			i = e.encode(buf, i, stop)
+		}
+
+		if ready := e.ready(); ready > 0 {
+			m, err := w.Write(e.out[:ready])
+			written += int64(m)
+			if err != nil {
+				return written, err
+			}
+			e.drop(ready)
+		}
+
+		// Keep limit bytes before i for match search.
+		if drop := i - e.limit; drop > 0 {
+			buf = buf[:copy(buf, buf[drop:])]
+			i -= drop
+		}
+	}
+
+	if read == 0 {
+		return 0, ErrEmptyInput
+	}
+
+	e.finish(crc)
+	m, err := w.Write(e.out)
+	written += int64(m)
+
+	return written, err
+}
+
+// BlockWriter buffers written data and compresses it into one independent block on each Flush.
+// Blocks are written back-to-back to the underlying writer without framing,
+// so containers record Sizes and RawSizes themselves.
+type BlockWriter struct {
+	w    io.Writer
+	opts *CompressOptions
+
+	buf      []byte
+	sizes    []int
+	rawSizes []int
+
+	closed bool
+}
+
+// NewBlockWriter returns a BlockWriter writing to w. Options nil means DefaultCompressOptions().
+func NewBlockWriter(w io.Writer, opts *CompressOptions) *BlockWriter {
+	if opts == nil {
+		opts = DefaultCompressOptions()
+	}
+
+	return &BlockWriter{w: w, opts: opts}
+}
+
+// Write buffers p for the current block.
+func (bw *BlockWriter) Write(p []byte) (int, error) {
+	if bw.closed {
+		return 0, ErrWriterClosed
+	}
+
+	bw.buf = append(bw.buf, p...)
+
+	return len(p), nil
+}
+
+// Flush compresses buffered data as one block and writes it.
+// Flush without buffered data writes nothing (empty blocks cannot be encoded).
+func (bw *BlockWriter) Flush() error {
+	if bw.closed {
+		return ErrWriterClosed
+	}
+	if len(bw.buf) == 0 {
+		return nil
+	}
+
+	enc, err := Compress(bw.buf, bw.opts)
+	if err != nil {
+		return err
+	}
+	if _, err := bw.w.Write(enc); err != nil {
+		return err
+	}
+
+	bw.sizes = append(bw.sizes, len(enc))
+	bw.rawSizes = append(bw.rawSizes, len(bw.buf))
+	bw.buf = bw.buf[:0]
+
+	return nil
+}
+
+// Sizes returns compressed sizes of blocks written so far.
+func (bw *BlockWriter) Sizes() []int {
+	return append([]int(nil), bw.sizes...)
+}
+
+// RawSizes returns decoded sizes of blocks written so far (outLens for DecompressNFromReader).
+func (bw *BlockWriter) RawSizes() []int {
+	return append([]int(nil), bw.rawSizes...)
+}
+
+// Close flushes the last block. It does not close the underlying writer.
+func (bw *BlockWriter) Close() error {
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	bw.closed = true
+
+	return nil
+}
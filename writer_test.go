@@ -0,0 +1,151 @@
+package lzss
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+)
+
+func TestCompressNToWriter(t *testing.T) {
+	blocks := [][]byte{
+		[]byte("first block, first block"),
+		bytes.Repeat([]byte("second "), 40),
+		[]byte("x"),
+	}
+	opts := &CompressOptions{Checksum: ChecksumSigned, SearchLimit: 512}
+
+	var buf bytes.Buffer
+	sizes, err := CompressNToWriter(&buf, blocks, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	total := 0
+	for i, size := range sizes {
+		enc, err := Compress(blocks[i], opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if size != len(enc) {
+			t.Fatalf("block %d size=%d want=%d", i, size, len(enc))
+		}
+		total += size
+	}
+	if len(sizes) != len(blocks) || total != buf.Len() {
+		t.Fatalf("sizes=%v written=%d", sizes, buf.Len())
+	}
+
+	outLens := []int{len(blocks[0]), len(blocks[1]), len(blocks[2])}
+	got, consumed, err := DecompressNFromReader(&buf, outLens, SignedLenientOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if consumed != int64(total) {
+		t.Fatalf("consumed=%d", consumed)
+	}
+	for i := range blocks {
+		if !bytes.Equal(got[i], blocks[i]) {
+			t.Fatalf("block %d mismatch", i)
+		}
+	}
+
+	sizes, err = CompressNToWriter(&buf, [][]byte{[]byte("ok"), nil}, nil)
+	if !errors.Is(err, ErrEmptyInput) || len(sizes) != 1 {
+		t.Fatalf("sizes=%v err=%v", sizes, err)
+	}
+}
+
+func TestCompressToWriter(t *testing.T) {
+	corpus := goldenCorpus()
+	for _, g := range goldenOutputs {
+		data, opts := corpus[g.corpus], goldenOptions[g.opts]
+
+		var buf bytes.Buffer
+		n, err := CompressToWriter(&buf, iotest.HalfReader(bytes.NewReader(data)), opts)
+		if err != nil {
+			t.Fatalf("%s/%s: %v", g.corpus, g.opts, err)
+		}
+
+		sum := sha256.Sum256(buf.Bytes())
+		if n != int64(buf.Len()) || buf.Len() != g.size || hex.EncodeToString(sum[:8]) != g.sum {
+			t.Fatalf("%s/%s: n=%d size=%d sum=%x, want %d %s", g.corpus, g.opts, n, buf.Len(), sum[:8], g.size, g.sum)
+		}
+	}
+
+	// Larger than one read chunk, so the window slides across reads; also one byte per read.
+	large := bytes.Repeat(corpus["mixed"], 3)
+	tests := []struct {
+		name string
+		data []byte
+		r    io.Reader
+	}{
+		{"large", large, iotest.HalfReader(bytes.NewReader(large))},
+		{"one_byte", corpus["mixed"], iotest.OneByteReader(bytes.NewReader(corpus["mixed"]))},
+	}
+	for _, tt := range tests {
+		want, err := Compress(tt.data, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var buf bytes.Buffer
+		if _, err := CompressToWriter(&buf, tt.r, nil); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.Bytes(), want) {
+			t.Fatalf("%s: output differs from Compress (len=%d want=%d)", tt.name, buf.Len(), len(want))
+		}
+	}
+
+	if _, err := CompressToWriter(io.Discard, bytes.NewReader(nil), nil); !errors.Is(err, ErrEmptyInput) {
+		t.Fatalf("empty input: %v", err)
+	}
+}
+
+func TestBlockWriter(t *testing.T) {
+	var buf bytes.Buffer
+	bw := NewBlockWriter(&buf, nil)
+
+	parts := [][]byte{[]byte("block one: "), []byte("written in two parts")}
+	for _, p := range parts {
+		if _, err := bw.Write(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	// Empty flush writes nothing.
+	if err := bw.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bw.Write(bytes.Repeat([]byte("two"), 50)); err != nil {
+		t.Fatal(err)
+	}
+	if err := bw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bw.Write([]byte("late")); !errors.Is(err, ErrWriterClosed) {
+		t.Fatalf("write after close: %v", err)
+	}
+
+	sizes, rawSizes := bw.Sizes(), bw.RawSizes()
+	if len(sizes) != 2 || sizes[0]+sizes[1] != buf.Len() {
+		t.Fatalf("sizes=%v written=%d", sizes, buf.Len())
+	}
+	if rawSizes[0] != len(parts[0])+len(parts[1]) || rawSizes[1] != 150 {
+		t.Fatalf("raw sizes=%v", rawSizes)
+	}
+
+	got, _, err := DecompressNFromReader(&buf, rawSizes, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got[0], bytes.Join(parts, nil)) || !bytes.Equal(got[1], bytes.Repeat([]byte("two"), 50)) {
+		t.Fatal("decoded blocks mismatch")
+	}
+}